@@ -0,0 +1,233 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pplcc/plotext/custplotter"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// DefaultTimeFormat is the default format of the time axis of a Chart.
+var DefaultTimeFormat = "2006-01-02\n15:04:05"
+
+// Pane is a pane of a Chart.
+type Pane struct {
+	// Plot is the plot of the pane.
+	Plot *plot.Plot
+
+	// Height is the relative height of the pane.
+	Height float64
+
+	// Size, if not the zero Size, is the size of the pane, e.g.
+	// Abs(1.5 * vg.Inch), and is used instead of Height.
+	Size Size
+}
+
+// Chart is a financial chart of panes stacked from top to bottom, e.g. a
+// price pane above a volume pane and an indicator pane. All panes share
+// the time axis, which is only labeled in the bottom pane (see StackX).
+type Chart struct {
+	// Data are the bars shown by the chart.
+	Data custplotter.TOHLCVs
+
+	// Panes are the panes from top to bottom.
+	Panes []*Pane
+
+	// TimeFormat is the format of the time axis of new panes.
+	TimeFormat string
+
+	// Grid determines if new panes get vertical gridlines
+	// at the ticks of the time axis.
+	Grid bool
+
+	// PadTop, PadBottom, PadLeft and PadRight are the paddings
+	// around the panes. PadY is the padding between the panes.
+	PadTop, PadBottom vg.Length
+	PadLeft, PadRight vg.Length
+	PadY              vg.Length
+}
+
+// NewChart creates a new chart without panes for the given data.
+func NewChart(data custplotter.TOHLCVer) (*Chart, error) {
+	cpy, err := custplotter.CopyTOHLCVs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Chart{
+		Data:       cpy,
+		TimeFormat: DefaultTimeFormat,
+		Grid:       true,
+		PadTop:     vg.Points(2),
+		PadBottom:  vg.Points(2),
+		PadLeft:    vg.Points(2),
+		PadRight:   vg.Points(2),
+		PadY:       vg.Points(2),
+	}, nil
+}
+
+// AddPane adds a pane with the given relative height and plotters
+// below the existing panes.
+func (ch *Chart) AddPane(height float64, ps ...plot.Plotter) (*Pane, error) {
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	p.X.Tick.Marker = plot.TimeTicks{Format: ch.TimeFormat}
+	if ch.Grid {
+		AddXGrid(p)
+	}
+	p.Add(ps...)
+
+	pane := &Pane{Plot: p, Height: height}
+	ch.Panes = append(ch.Panes, pane)
+	return pane, nil
+}
+
+// AddCandlesticks adds a pane with the given relative height
+// showing the data of the chart as candlesticks.
+func (ch *Chart) AddCandlesticks(height float64) (*Pane, error) {
+	sticks, err := custplotter.NewCandlesticks(ch.Data)
+	if err != nil {
+		return nil, err
+	}
+	return ch.AddPane(height, sticks)
+}
+
+// AddVolume adds a pane with the given relative height
+// showing the volume of the data of the chart.
+func (ch *Chart) AddVolume(height float64) (*Pane, error) {
+	bars, err := custplotter.NewVBars(ch.Data)
+	if err != nil {
+		return nil, err
+	}
+	return ch.AddPane(height, bars)
+}
+
+// table returns the table of the panes of the chart.
+func (ch *Chart) table() Table {
+	tab := Table{
+		RowHeights: make([]float64, len(ch.Panes)),
+		ColWidths:  []float64{1},
+		PadTop:     ch.PadTop,
+		PadBottom:  ch.PadBottom,
+		PadLeft:    ch.PadLeft,
+		PadRight:   ch.PadRight,
+		PadY:       ch.PadY,
+	}
+	var sized bool
+	for j, pane := range ch.Panes {
+		tab.RowHeights[j] = pane.Height
+		if pane.Size != (Size{}) {
+			sized = true
+		}
+	}
+	if sized {
+		tab.RowSizes = relSizes(tab.RowHeights)
+		for j, pane := range ch.Panes {
+			if pane.Size != (Size{}) {
+				tab.RowSizes[j] = pane.Size
+			}
+		}
+	}
+	return tab
+}
+
+// Validate returns an error if the chart is invalid, i.e. if it has
+// no panes, if a pane has no plot or if a height or a size of a pane
+// is invalid or a padding is negative (see Table.Validate).
+func (ch *Chart) Validate() error {
+	for j, pane := range ch.Panes {
+		if pane == nil || pane.Plot == nil {
+			return fmt.Errorf("plotext: pane %d has no plot", j)
+		}
+	}
+	return ch.table().Validate()
+}
+
+// Draw draws the chart to c. The chart should be valid, see Validate.
+//
+// The X axes of the panes are shared by StackX while the chart is drawn
+// and restored afterwards, so the plots of the panes are not changed.
+func (ch *Chart) Draw(c draw.Canvas) {
+	if len(ch.Panes) == 0 {
+		return
+	}
+
+	plots := make([]*plot.Plot, len(ch.Panes))
+	axes := make([]plot.Axis, len(ch.Panes))
+	for j, pane := range ch.Panes {
+		plots[j] = pane.Plot
+		axes[j] = pane.Plot.X
+	}
+	defer func() {
+		for j, p := range plots {
+			p.X = axes[j]
+		}
+	}()
+
+	StackX(plots)
+	for j, dc := range ch.table().AlignStacked(plots, c) {
+		plots[j].Draw(dc)
+	}
+}
+
+// WriterTo returns an io.WriterTo that will write the chart as
+// the specified image format. It returns an error if the chart
+// is invalid (see Validate) or does not fit into w and h.
+//
+// Supported formats are:
+//
+//	eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
+func (ch *Chart) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
+	if err := ch.Validate(); err != nil {
+		return nil, err
+	}
+	c, err := draw.NewFormattedCanvas(w, h, format)
+	if err != nil {
+		return nil, err
+	}
+	dc := draw.New(c)
+	if err := ch.table().validateCanvas(dc); err != nil {
+		return nil, err
+	}
+	ch.Draw(dc)
+	return c, nil
+}
+
+// Save saves the chart to an image file. The file format is
+// determined by the extension (see WriterTo).
+func (ch *Chart) Save(w, h vg.Length, file string) (err error) {
+	format := strings.ToLower(filepath.Ext(file))
+	if len(format) != 0 {
+		format = format[1:]
+	}
+	c, err := ch.WriterTo(w, h, format)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := f.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+
+	_, err = c.WriteTo(f)
+	return err
+}
@@ -0,0 +1,125 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+
+	"github.com/pplcc/plotext/internal"
+)
+
+func newTestChart() *Chart {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	ch, err := NewChart(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+	ch.TimeFormat = "15:04"
+
+	if _, err := ch.AddCandlesticks(3); err != nil {
+		panic(err)
+	}
+	if _, err := ch.AddVolume(1); err != nil {
+		panic(err)
+	}
+
+	momentum := make(plotter.XYs, len(testTOHLCVs)-1)
+	for i := range momentum {
+		momentum[i].X = testTOHLCVs[i+1].T
+		momentum[i].Y = testTOHLCVs[i+1].C - testTOHLCVs[i].C
+	}
+	line, err := plotter.NewLine(momentum)
+	if err != nil {
+		panic(err)
+	}
+	pane, err := ch.AddPane(1, line)
+	if err != nil {
+		panic(err)
+	}
+	pane.Plot.X.Label.Text = "Time"
+
+	return ch
+}
+
+func TestChart(t *testing.T) {
+	ch := newTestChart()
+
+	testFile := "testdata/chart.png"
+	if err := ch.Save(vg.Points(250), vg.Points(200), testFile); err != nil {
+		panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
+
+func TestChartWriterTo(t *testing.T) {
+	ch := newTestChart()
+
+	for _, format := range []string{"eps", "jpg", "pdf", "png", "svg", "tiff"} {
+		wt, err := ch.WriterTo(vg.Points(250), vg.Points(200), format)
+		if err != nil {
+			t.Errorf("unexpected error for format %q: %v", format, err)
+			continue
+		}
+		var buf bytes.Buffer
+		if _, err := wt.WriteTo(&buf); err != nil {
+			t.Errorf("unexpected error writing format %q: %v", format, err)
+		}
+		if buf.Len() == 0 {
+			t.Errorf("no output for format %q", format)
+		}
+	}
+
+	if _, err := ch.WriterTo(vg.Points(250), vg.Points(200), "bmp"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestChartValidate(t *testing.T) {
+	for _, height := range []float64{0, -1, math.NaN()} {
+		ch := newTestChart()
+		ch.Panes[1].Height = height
+		if _, err := ch.WriterTo(vg.Points(250), vg.Points(200), "png"); err == nil {
+			t.Errorf("expected error for pane height %v", height)
+		}
+	}
+
+	ch := newTestChart()
+	if _, err := ch.WriterTo(vg.Points(2), vg.Points(2), "png"); err == nil {
+		t.Error("expected error for paddings larger than the canvas")
+	}
+
+	ch.Panes = append(ch.Panes, &Pane{Height: 1})
+	if err := ch.Validate(); err == nil {
+		t.Error("expected error for pane without plot")
+	}
+
+	if err := (&Chart{}).Validate(); err == nil {
+		t.Error("expected error for chart without panes")
+	}
+}
+
+func TestChartDrawRestoresAxes(t *testing.T) {
+	ch := newTestChart()
+	top := ch.Panes[0].Plot
+	top.X.Label.Text = "Time"
+
+	if _, err := ch.WriterTo(vg.Points(250), vg.Points(200), "png"); err != nil {
+		panic(err)
+	}
+
+	if _, ok := top.X.Tick.Marker.(unlabeledTicks); ok {
+		t.Error("tick labels of the top pane are hidden after drawing")
+	}
+	if top.X.Label.Text != "Time" {
+		t.Errorf("axis label of the top pane is %q after drawing, want %q", top.X.Label.Text, "Time")
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/pplcc/plotext/custplotter"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// CursorValue is a named series of values, e.g. of an indicator (see
+// custplotter.IndicatorValues), with one value for each bar of the data.
+type CursorValue struct {
+	Name   string
+	Values []float64
+}
+
+// Cursor implements the plot.Plotter interface, drawing a vertical line at
+// time T through the data canvas and, if Lines is not empty, a data box next
+// to the line at the top of the data canvas. A cursor outside the range of
+// the X axis is not drawn.
+type Cursor struct {
+	T float64
+
+	// LineStyle is the style of the line and the border of the box.
+	draw.LineStyle
+
+	// Lines are the lines of text in the data box.
+	Lines []string
+
+	// TextStyle is the style of the text in the data box.
+	TextStyle draw.TextStyle
+
+	// BoxColor is the fill color of the data box.
+	BoxColor color.Color
+
+	// Padding is the padding between the text and the border of the box
+	// and between the box and the line.
+	Padding vg.Length
+}
+
+// NewCursor creates a new cursor at time t
+// with a data box showing the given lines.
+func NewCursor(t float64, lines ...string) (*Cursor, error) {
+	if err := plotter.CheckFloats(t); err != nil {
+		return nil, err
+	}
+
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(6))
+	if err != nil {
+		return nil, err
+	}
+
+	lineStyle := plotter.DefaultLineStyle
+	lineStyle.Width = vg.Points(0.5)
+	lineStyle.Color = color.Gray{Y: 96}
+	lineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+
+	return &Cursor{
+		T:         t,
+		LineStyle: lineStyle,
+		Lines:     append([]string(nil), lines...),
+		TextStyle: draw.TextStyle{
+			Color: color.Black,
+			Font:  font,
+		},
+		BoxColor: color.RGBA{R: 255, G: 255, B: 224, A: 255},
+		Padding:  vg.Points(1.5),
+	}, nil
+}
+
+// AddCursor adds a cursor at the bar of data nearest to time t to each of
+// the plots, e.g. the plots of a column aligned by Table.Align or the
+// panes of a Chart. The cursor of the first plot shows a data box with the
+// open, high, low, close and volume of the bar and the values of the bar.
+// Plots that are nil are skipped.
+//
+// The cursors are returned in the order of the plots, so that their styles
+// can be changed before the plots are drawn.
+func AddCursor(plots []*plot.Plot, data custplotter.TOHLCVer, t float64, values ...CursorValue) ([]*Cursor, error) {
+	if data.Len() == 0 {
+		return nil, errors.New("plotext: no bars for cursor")
+	}
+	for _, v := range values {
+		if len(v.Values) != data.Len() {
+			return nil, fmt.Errorf("plotext: %d values of %q for %d bars", len(v.Values), v.Name, data.Len())
+		}
+	}
+
+	i := nearestBar(data, t)
+	bt, o, h, l, c, vol := data.TOHLCV(i)
+	lines := []string{
+		fmt.Sprintf("O %.2f", o),
+		fmt.Sprintf("H %.2f", h),
+		fmt.Sprintf("L %.2f", l),
+		fmt.Sprintf("C %.2f", c),
+		fmt.Sprintf("V %.0f", vol),
+	}
+	for _, v := range values {
+		lines = append(lines, fmt.Sprintf("%s %.2f", v.Name, v.Values[i]))
+	}
+
+	var cursors []*Cursor
+	for _, p := range plots {
+		if p == nil {
+			continue
+		}
+		cur, err := NewCursor(bt)
+		if err != nil {
+			return nil, err
+		}
+		if len(cursors) == 0 {
+			cur.Lines = lines
+		}
+		p.Add(cur)
+		cursors = append(cursors, cur)
+	}
+	return cursors, nil
+}
+
+// nearestBar returns the index of the bar of data with the time nearest to t.
+func nearestBar(data custplotter.TOHLCVer, t float64) int {
+	var nearest int
+	var dist float64
+	for i := 0; i < data.Len(); i++ {
+		bt, _, _, _, _, _ := data.TOHLCV(i)
+		d := bt - t
+		if d < 0 {
+			d = -d
+		}
+		if i == 0 || d < dist {
+			nearest, dist = i, d
+		}
+	}
+	return nearest
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+// The data box is placed to the right of the line and to the left
+// of it if it does not fit into the data canvas.
+func (cur *Cursor) Plot(c draw.Canvas, plt *plot.Plot) {
+	if cur.T < plt.X.Min || cur.T > plt.X.Max {
+		return
+	}
+
+	trX, _ := plt.Transforms(&c)
+	x := trX(cur.T)
+	c.StrokeLine2(cur.LineStyle, x, c.Min.Y, x, c.Max.Y)
+
+	if len(cur.Lines) == 0 {
+		return
+	}
+
+	txt := strings.Join(cur.Lines, "\n")
+	w := cur.TextStyle.Width(txt) + 2*cur.Padding
+	h := cur.TextStyle.Height(txt) + 2*cur.Padding
+
+	bx := x + cur.Padding
+	if bx+w > c.Max.X {
+		bx = x - cur.Padding - w
+	}
+	by := c.Max.Y - cur.Padding - h
+
+	box := []vg.Point{{X: bx, Y: by}, {X: bx + w, Y: by}, {X: bx + w, Y: by + h}, {X: bx, Y: by + h}}
+	if cur.BoxColor != nil {
+		c.FillPolygon(cur.BoxColor, box)
+	}
+	border := cur.LineStyle
+	border.Dashes = nil
+	c.StrokeLines(border, append(box, box[0]))
+	c.FillText(cur.TextStyle, vg.Point{X: bx + cur.Padding, Y: by + cur.Padding}, txt)
+}
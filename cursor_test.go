@@ -0,0 +1,64 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/internal"
+)
+
+func TestAddCursor(t *testing.T) {
+	ch := newTestChart()
+
+	sma, err := custplotter.NewSMA(3)
+	if err != nil {
+		panic(err)
+	}
+	values := custplotter.IndicatorValues(sma, ch.Data)
+
+	plots := make([]*plot.Plot, len(ch.Panes))
+	for j, pane := range ch.Panes {
+		plots[j] = pane.Plot
+	}
+
+	// between the bars 5 and 6, nearer to bar 6
+	bar := ch.Data[6]
+	at := bar.T - (bar.T-ch.Data[5].T)/4
+	cursors, err := AddCursor(plots, ch.Data, at, CursorValue{Name: "SMA(3)", Values: values})
+	if err != nil {
+		panic(err)
+	}
+
+	if len(cursors) != len(plots) {
+		t.Fatalf("unexpected number of cursors %d, want %d", len(cursors), len(plots))
+	}
+	for i, cur := range cursors {
+		if cur.T != bar.T {
+			t.Errorf("cursor %d at %v, want %v", i, cur.T, bar.T)
+		}
+		if i > 0 && len(cur.Lines) != 0 {
+			t.Errorf("cursor %d has a data box", i)
+		}
+	}
+	if n := len(cursors[0].Lines); n != 6 {
+		t.Errorf("unexpected number of lines %d in the data box, want 6", n)
+	}
+
+	testFile := "testdata/cursor.png"
+	if err := ch.Save(vg.Points(250), vg.Points(200), testFile); err != nil {
+		panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+
+	if _, err := AddCursor(plots, ch.Data, at, CursorValue{Name: "short", Values: values[1:]}); err == nil {
+		t.Error("expected error for too few values")
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"fmt"
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// CalloutPlacement determines where a callout is placed relative to its bar.
+type CalloutPlacement int
+
+const (
+	// CalloutAuto places the callout above or below the bar,
+	// whichever avoids overlapping bars and other callouts.
+	CalloutAuto CalloutPlacement = iota
+	// CalloutAbove places the callout above the high of the bar.
+	CalloutAbove
+	// CalloutBelow places the callout below the low of the bar.
+	CalloutBelow
+)
+
+// Callout is a text box pointing at the bar with the given index.
+// The leader line points at the high of the bar if the box is placed
+// above the bar and at the low if it is placed below.
+type Callout struct {
+	Index     int
+	Text      string
+	Placement CalloutPlacement
+}
+
+// Callouts implements the Plotter interface, drawing text boxes
+// with leader lines pointing at bars.
+//
+// Callouts are placed in the order given. A callout is moved
+// away from its bar step by step until it overlaps neither the bars
+// nor the callouts placed before it. If no such position within
+// the data canvas is found, overlapping bars and then leaving the
+// data canvas is accepted. If there is still none, the callout is
+// placed at the preferred position next to the bar. Callouts with
+// an index out of the range of the bars are skipped.
+type Callouts struct {
+	TOHLCVs
+
+	Callouts []Callout
+
+	// LineStyle is the style of the leader lines and the box borders.
+	draw.LineStyle
+
+	// TextStyle is the style of the texts.
+	TextStyle draw.TextStyle
+
+	// BoxColor is the fill color of the boxes.
+	BoxColor color.Color
+
+	// Padding is the padding between the text and the border of its box.
+	Padding vg.Length
+
+	// Distance is the distance between a bar and its callout. Colliding
+	// callouts are moved away from the bar in steps of Distance.
+	Distance vg.Length
+
+	// MaxSteps is the maximum number of steps a callout is moved.
+	MaxSteps int
+
+	// Viewport, if not nil, restricts the callouts which are drawn to the
+	// ones of bars within the viewport. Only these bars are avoided.
+	Viewport *Viewport
+}
+
+// NewCallouts creates a new callout plotter for the given bars and callouts.
+func NewCallouts(TOHLCV TOHLCVer, callouts ...Callout) (*Callouts, error) {
+	cpy, err := CopyTOHLCVs(TOHLCV)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, co := range callouts {
+		if co.Index < 0 || co.Index >= len(cpy) {
+			return nil, fmt.Errorf("custplotter: callout index %d out of range [0, %d)", co.Index, len(cpy))
+		}
+	}
+
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(6))
+	if err != nil {
+		return nil, err
+	}
+
+	lineStyle := plotter.DefaultLineStyle
+	lineStyle.Width = vg.Points(0.5)
+
+	return &Callouts{
+		TOHLCVs:   cpy,
+		Callouts:  append([]Callout(nil), callouts...),
+		LineStyle: lineStyle,
+		TextStyle: draw.TextStyle{
+			Color: color.Black,
+			Font:  font,
+		},
+		BoxColor: color.RGBA{R: 255, G: 255, B: 224, A: 255},
+		Padding:  vg.Points(1.5),
+		Distance: vg.Points(6),
+		MaxSteps: 4,
+	}, nil
+}
+
+// calloutBox is a placed callout.
+type calloutBox struct {
+	text   string
+	anchor vg.Point
+	box    vg.Rectangle
+}
+
+// overlaps returns whether the rectangles a and b overlap.
+func overlaps(a, b vg.Rectangle) bool {
+	return a.Min.X < b.Max.X && b.Min.X < a.Max.X && a.Min.Y < b.Max.Y && b.Min.Y < a.Max.Y
+}
+
+// hasOverlap returns whether r overlaps any of the rectangles rs.
+func hasOverlap(r vg.Rectangle, rs []vg.Rectangle) bool {
+	for _, o := range rs {
+		if overlaps(r, o) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains returns whether r lies within c.
+func contains(c draw.Canvas, r vg.Rectangle) bool {
+	return r.Min.X >= c.Min.X && r.Max.X <= c.Max.X && r.Min.Y >= c.Min.Y && r.Max.Y <= c.Max.Y
+}
+
+// layout places the callouts.
+func (co *Callouts) layout(c draw.Canvas, plt *plot.Plot) []calloutBox {
+	trX, trY := plt.Transforms(&c)
+
+	// obstacles are the bars and the callouts placed so far
+	obstacles := make([]vg.Rectangle, 0, len(co.TOHLCVs)+len(co.Callouts))
+	for _, b := range co.TOHLCVs {
+		if !canTransform(plt.Y, b.L) || !co.Viewport.Contains(b.T) {
+			continue
+		}
+		x := trX(b.T)
+		obstacles = append(obstacles, vg.Rectangle{
+			Min: vg.Point{X: x - co.Padding, Y: trY(b.L)},
+			Max: vg.Point{X: x + co.Padding, Y: trY(b.H)},
+		})
+	}
+
+	boxes := make([]calloutBox, 0, len(co.Callouts))
+	for _, callout := range co.Callouts {
+		if callout.Index < 0 || callout.Index >= len(co.TOHLCVs) {
+			continue
+		}
+		b := co.TOHLCVs[callout.Index]
+		if !canTransform(plt.Y, b.L) || !co.Viewport.Contains(b.T) {
+			continue
+		}
+		x := trX(b.T)
+		w := co.TextStyle.Width(callout.Text) + 2*co.Padding
+		h := co.TextStyle.Height(callout.Text) + 2*co.Padding
+
+		// keep the box within the data canvas horizontally
+		left := x - w/2
+		if left+w > c.Max.X {
+			left = c.Max.X - w
+		}
+		if left < c.Min.X {
+			left = c.Min.X
+		}
+
+		above := func(d vg.Length) calloutBox {
+			y := trY(b.H) + d
+			return calloutBox{
+				text:   callout.Text,
+				anchor: vg.Point{X: x, Y: trY(b.H)},
+				box:    vg.Rectangle{Min: vg.Point{X: left, Y: y}, Max: vg.Point{X: left + w, Y: y + h}},
+			}
+		}
+		below := func(d vg.Length) calloutBox {
+			y := trY(b.L) - d
+			return calloutBox{
+				text:   callout.Text,
+				anchor: vg.Point{X: x, Y: trY(b.L)},
+				box:    vg.Rectangle{Min: vg.Point{X: left, Y: y - h}, Max: vg.Point{X: left + w, Y: y}},
+			}
+		}
+
+		var candidates []calloutBox
+		for step := 1; step <= co.MaxSteps || step == 1; step++ {
+			d := co.Distance * vg.Length(step)
+			switch callout.Placement {
+			case CalloutAbove:
+				candidates = append(candidates, above(d))
+			case CalloutBelow:
+				candidates = append(candidates, below(d))
+			default:
+				candidates = append(candidates, above(d), below(d))
+			}
+		}
+
+		placed := candidates[0]
+		// first avoid the bars and the callouts within the data canvas,
+		// then only the callouts within and finally outside the data canvas
+		nBars := len(obstacles) - len(boxes)
+		passes := []struct {
+			from   int
+			inside bool
+		}{{0, true}, {nBars, true}, {nBars, false}}
+	search:
+		for _, pass := range passes {
+			for _, cand := range candidates {
+				if pass.inside && !contains(c, cand.box) {
+					continue
+				}
+				if hasOverlap(cand.box, obstacles[pass.from:]) {
+					continue
+				}
+				placed = cand
+				break search
+			}
+		}
+
+		obstacles = append(obstacles, placed.box)
+		boxes = append(boxes, placed)
+	}
+	return boxes
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (co *Callouts) Plot(c draw.Canvas, plt *plot.Plot) {
+	for _, cb := range co.layout(c, plt) {
+		// the leader line ends at the nearest edge of the box
+		end := vg.Point{X: cb.anchor.X, Y: cb.box.Min.Y}
+		if cb.box.Max.Y <= cb.anchor.Y {
+			end.Y = cb.box.Max.Y
+		}
+		if end.X < cb.box.Min.X {
+			end.X = cb.box.Min.X
+		}
+		if end.X > cb.box.Max.X {
+			end.X = cb.box.Max.X
+		}
+		c.StrokeLine2(co.LineStyle, cb.anchor.X, cb.anchor.Y, end.X, end.Y)
+
+		outline := []vg.Point{
+			cb.box.Min,
+			{X: cb.box.Max.X, Y: cb.box.Min.Y},
+			cb.box.Max,
+			{X: cb.box.Min.X, Y: cb.box.Max.Y},
+			cb.box.Min,
+		}
+		if co.BoxColor != nil {
+			c.FillPolygon(co.BoxColor, outline)
+		}
+		c.StrokeLines(co.LineStyle, outline)
+		c.FillText(co.TextStyle, vg.Point{X: cb.box.Min.X + co.Padding, Y: cb.box.Min.Y + co.Padding}, cb.text)
+	}
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+// The glyph boxes reserve the space for each callout at
+// its preferred position next to its bar.
+func (co *Callouts) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	boxes := make([]plot.GlyphBox, 0, len(co.Callouts))
+	for _, callout := range co.Callouts {
+		if callout.Index < 0 || callout.Index >= len(co.TOHLCVs) {
+			continue
+		}
+		b := co.TOHLCVs[callout.Index]
+		if !canTransform(plt.Y, b.L) || !co.Viewport.Contains(b.T) {
+			continue
+		}
+		w := co.TextStyle.Width(callout.Text) + 2*co.Padding
+		h := co.TextStyle.Height(callout.Text) + 2*co.Padding
+		box := plot.GlyphBox{X: plt.X.Norm(b.T)}
+		if callout.Placement == CalloutBelow {
+			box.Y = plt.Y.Norm(b.L)
+			box.Rectangle = vg.Rectangle{
+				Min: vg.Point{X: -w / 2, Y: -co.Distance - h},
+				Max: vg.Point{X: w / 2, Y: 0},
+			}
+		} else {
+			box.Y = plt.Y.Norm(b.H)
+			box.Rectangle = vg.Rectangle{
+				Min: vg.Point{X: -w / 2, Y: 0},
+				Max: vg.Point{X: w / 2, Y: co.Distance + h},
+			}
+		}
+		boxes = append(boxes, box)
+	}
+	return boxes
+}
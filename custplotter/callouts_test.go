@@ -0,0 +1,83 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestNewCalloutsIndex(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	_, err := custplotter.NewCallouts(testTOHLCVs, custplotter.Callout{Index: len(testTOHLCVs), Text: "x"})
+	if err == nil {
+		t.Error("expected error for index out of range")
+	}
+
+	// indexes out of range added after the construction are skipped
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	callouts, err := custplotter.NewCallouts(testTOHLCVs, custplotter.Callout{Index: 2, Text: "x"})
+	if err != nil {
+		log.Panic(err)
+	}
+	callouts.Callouts = append(callouts.Callouts, custplotter.Callout{Index: 99, Text: "y"}, custplotter.Callout{Index: -1, Text: "z"})
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(sticks, callouts)
+	if boxes := callouts.GlyphBoxes(p); len(boxes) != 1 {
+		t.Errorf("unexpected number of glyph boxes %d, want 1", len(boxes))
+	}
+	if _, err := p.WriterTo(180, 100, "png"); err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestNewCallouts(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	callouts, err := custplotter.NewCallouts(testTOHLCVs,
+		custplotter.Callout{Index: 2, Text: "breakout"},
+		// the neighbouring callouts are moved to avoid the first one
+		custplotter.Callout{Index: 3, Text: "retest"},
+		custplotter.Callout{Index: 4, Text: "spike"},
+		custplotter.Callout{Index: 11, Text: "top", Placement: custplotter.CalloutAbove},
+		custplotter.Callout{Index: 18, Text: "low", Placement: custplotter.CalloutBelow},
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(sticks, callouts)
+
+	testFile := "testdata/callouts.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
@@ -40,6 +40,15 @@ type Candlesticks struct {
 	// draw the sticks and the borders of the candle. Thus a candle's fill color is also
 	// used for the borders and sticks.
 	FixedLineColor bool
+
+	// PositiveOnly determines if DataRange ignores bars with non-positive
+	// prices, which cannot be shown on a log scale (plot.LogScale).
+	// Such bars are never drawn on a log scale.
+	PositiveOnly bool
+
+	// Viewport, if not nil, restricts the bars which are drawn and
+	// covered by DataRange to the bars within the viewport.
+	Viewport *Viewport
 }
 
 // NewCandlesticks creates as new candlestick plotter for
@@ -66,6 +75,13 @@ func (sticks *Candlesticks) Plot(c draw.Canvas, plt *plot.Plot) {
 	lineStyle := sticks.LineStyle
 
 	for _, TOHLCV := range sticks.TOHLCVs {
+		if !sticks.Viewport.Contains(TOHLCV.T) {
+			continue
+		}
+		if !canTransform(plt.Y, TOHLCV.L) {
+			continue
+		}
+
 		var fillColor color.Color
 		if TOHLCV.C >= TOHLCV.O {
 			fillColor = sticks.ColorUp
@@ -107,6 +123,12 @@ func (sticks *Candlesticks) DataRange() (xMin, xMax, yMin, yMax float64) {
 	yMin = math.Inf(1)
 	yMax = math.Inf(-1)
 	for _, TOHLCV := range sticks.TOHLCVs {
+		if !sticks.Viewport.Contains(TOHLCV.T) {
+			continue
+		}
+		if sticks.PositiveOnly && TOHLCV.L <= 0 {
+			continue
+		}
 		xMin = math.Min(xMin, TOHLCV.T)
 		xMax = math.Max(xMax, TOHLCV.T)
 		yMin = math.Min(yMin, TOHLCV.L)
@@ -115,6 +137,24 @@ func (sticks *Candlesticks) DataRange() (xMin, xMax, yMin, yMax float64) {
 	return
 }
 
+// WindowDataRange returns the Y range of the bars with T in [xmin, xmax]
+// like DataRange, e.g. to fit the Y axis to the bars within a window.
+func (sticks *Candlesticks) WindowDataRange(xmin, xmax float64) (ymin, ymax float64) {
+	ymin = math.Inf(1)
+	ymax = math.Inf(-1)
+	for _, TOHLCV := range sticks.TOHLCVs {
+		if TOHLCV.T < xmin || TOHLCV.T > xmax || !sticks.Viewport.Contains(TOHLCV.T) {
+			continue
+		}
+		if sticks.PositiveOnly && TOHLCV.L <= 0 {
+			continue
+		}
+		ymin = math.Min(ymin, TOHLCV.L)
+		ymax = math.Max(ymax, TOHLCV.H)
+	}
+	return
+}
+
 // GlyphBoxes implements the GlyphBoxes method
 // of the plot.GlyphBoxer interface.
 // We just return 2 glyph boxes at xmin, ymin and xmax, ymax
@@ -140,3 +180,49 @@ func (sticks *Candlesticks) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 
 	return boxes
 }
+
+// Thumbnail implements the Thumbnail method
+// of the plot.Thumbnailer interface.
+// It draws a miniature up candle next to a down candle.
+func (sticks *Candlesticks) Thumbnail(c *draw.Canvas) {
+	w := c.Max.X - c.Min.X
+	h := c.Max.Y - c.Min.Y
+	cw := sticks.CandleWidth
+	if cw > w/3 {
+		cw = w / 3
+	}
+
+	// low, min(open, close), max(open, close) and high relative to the height
+	candles := []struct {
+		x, l, minoc, maxoc, h float64
+		fillColor             color.Color
+	}{
+		{x: 1.0 / 3, l: 0.1, minoc: 0.25, maxoc: 0.65, h: 0.8, fillColor: sticks.ColorUp},
+		{x: 2.0 / 3, l: 0.2, minoc: 0.35, maxoc: 0.75, h: 0.9, fillColor: sticks.ColorDown},
+	}
+
+	lineStyle := sticks.LineStyle
+	for _, cdl := range candles {
+		if !sticks.FixedLineColor {
+			lineStyle.Color = cdl.fillColor
+		}
+		x := c.Min.X + vg.Length(cdl.x)*w
+		yl := c.Min.Y + vg.Length(cdl.l)*h
+		yminoc := c.Min.Y + vg.Length(cdl.minoc)*h
+		ymaxoc := c.Min.Y + vg.Length(cdl.maxoc)*h
+		yh := c.Min.Y + vg.Length(cdl.h)*h
+
+		c.StrokeLine2(lineStyle, x, yh, x, ymaxoc)
+		c.StrokeLine2(lineStyle, x, yl, x, yminoc)
+
+		poly := []vg.Point{
+			{X: x - cw/2, Y: ymaxoc},
+			{X: x + cw/2, Y: ymaxoc},
+			{X: x + cw/2, Y: yminoc},
+			{X: x - cw/2, Y: yminoc},
+			{X: x - cw/2, Y: ymaxoc},
+		}
+		c.FillPolygon(cdl.fillColor, poly)
+		c.StrokeLines(lineStyle, poly)
+	}
+}
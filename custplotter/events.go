@@ -0,0 +1,159 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// EventCategory is the category of an event.
+type EventCategory int
+
+const (
+	// Earnings are earnings releases.
+	Earnings EventCategory = iota
+	// Dividend are dividend payments.
+	Dividend
+	// Split are stock splits.
+	Split
+	// OtherEvent are all other events.
+	OtherEvent
+)
+
+// Event is an event at time T.
+type Event struct {
+	T        float64
+	Category EventCategory
+
+	// Text is written next to the flag of the event.
+	Text string
+}
+
+// EventStyle is the appearance of the flags of an event category.
+type EventStyle struct {
+	// Letter is written into the flag.
+	Letter string
+
+	// Color is the fill color of the flag.
+	Color color.Color
+}
+
+// DefaultEventStyles are the default styles of the event categories.
+var DefaultEventStyles = map[EventCategory]EventStyle{
+	Earnings:   {Letter: "E", Color: color.RGBA{R: 0, G: 0, B: 196, A: 255}},
+	Dividend:   {Letter: "D", Color: color.RGBA{R: 0, G: 128, B: 0, A: 255}},
+	Split:      {Letter: "S", Color: color.RGBA{R: 196, G: 128, B: 0, A: 255}},
+	OtherEvent: {Letter: "!", Color: color.RGBA{R: 128, G: 128, B: 128, A: 255}},
+}
+
+// EventMarkers implements the Plotter interface, drawing small labeled
+// flags along the bottom or the top edge of the data canvas. Events
+// outside the range of the X axis are skipped. EventMarkers does not
+// implement DataRanger, so it does not change the range of the axes.
+type EventMarkers struct {
+	Events []Event
+
+	// Top determines if the flags are placed along the top edge of the data
+	// canvas, e. g. of a VBars plot, instead of along the bottom edge.
+	Top bool
+
+	// Styles are the styles of the event categories.
+	Styles map[EventCategory]EventStyle
+
+	// LineStyle is the style used to draw the flag poles.
+	draw.LineStyle
+
+	// PoleLength is the length of the flag poles.
+	PoleLength vg.Length
+
+	// FlagTextStyle is the style of the letters in the flags.
+	FlagTextStyle draw.TextStyle
+
+	// TextStyle is the style of the event texts.
+	TextStyle draw.TextStyle
+
+	// Padding is the padding between a letter and the border of its flag.
+	Padding vg.Length
+}
+
+// NewEventMarkers creates a new event plotter for the given events.
+func NewEventMarkers(events ...Event) (*EventMarkers, error) {
+	for _, e := range events {
+		if err := plotter.CheckFloats(e.T); err != nil {
+			return nil, err
+		}
+	}
+
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(6))
+	if err != nil {
+		return nil, err
+	}
+
+	styles := make(map[EventCategory]EventStyle, len(DefaultEventStyles))
+	for k, v := range DefaultEventStyles {
+		styles[k] = v
+	}
+
+	return &EventMarkers{
+		Events:     append([]Event(nil), events...),
+		Styles:     styles,
+		LineStyle:  plotter.DefaultLineStyle,
+		PoleLength: vg.Points(12),
+		FlagTextStyle: draw.TextStyle{
+			Color:  color.White,
+			Font:   font,
+			XAlign: draw.XCenter,
+			YAlign: draw.YCenter,
+		},
+		TextStyle: draw.TextStyle{
+			Color:  color.Black,
+			Font:   font,
+			YAlign: draw.YCenter,
+		},
+		Padding: vg.Points(1),
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (em *EventMarkers) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, _ := plt.Transforms(&c)
+
+	for _, e := range em.Events {
+		if e.T < plt.X.Min || e.T > plt.X.Max {
+			continue
+		}
+
+		sty, ok := em.Styles[e.Category]
+		if !ok {
+			sty = em.Styles[OtherEvent]
+		}
+
+		w := em.FlagTextStyle.Width(sty.Letter) + 2*em.Padding
+		h := em.FlagTextStyle.Height(sty.Letter) + 2*em.Padding
+		x := trX(e.T)
+
+		// pole from the edge of the canvas to the far end of the flag
+		y0, y1, flagMin := c.Min.Y, c.Min.Y+em.PoleLength, c.Min.Y+em.PoleLength-h
+		if em.Top {
+			y0, y1, flagMin = c.Max.Y, c.Max.Y-em.PoleLength, c.Max.Y-em.PoleLength
+		}
+		c.StrokeLine2(em.LineStyle, x, y0, x, y1)
+
+		flag := []vg.Point{{X: x, Y: flagMin}, {X: x + w, Y: flagMin}, {X: x + w, Y: flagMin + h}, {X: x, Y: flagMin + h}}
+		if sty.Color != nil {
+			c.FillPolygon(sty.Color, flag)
+		}
+		c.FillText(em.FlagTextStyle, vg.Point{X: x + w/2, Y: flagMin + h/2}, sty.Letter)
+
+		if e.Text != "" {
+			c.FillText(em.TextStyle, vg.Point{X: x + w + em.Padding, Y: flagMin + h/2}, e.Text)
+		}
+	}
+}
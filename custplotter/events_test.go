@@ -0,0 +1,51 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestNewEventMarkers(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	events, err := custplotter.NewEventMarkers(
+		custplotter.Event{T: testTOHLCVs[2].T, Category: custplotter.Earnings, Text: "Q3"},
+		custplotter.Event{T: testTOHLCVs[9].T, Category: custplotter.Dividend},
+		custplotter.Event{T: testTOHLCVs[14].T, Category: custplotter.Split, Text: "2:1"},
+		// outside of the X range, skipped
+		custplotter.Event{T: testTOHLCVs[19].T + 3600, Category: custplotter.OtherEvent},
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(sticks, events)
+
+	testFile := "testdata/eventmarkers.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
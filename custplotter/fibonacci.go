@@ -0,0 +1,229 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// DefaultFibonacciLevels are the default ratios of the levels drawn by FibRetracement.
+var DefaultFibonacciLevels = []float64{0, 0.236, 0.382, 0.5, 0.618, 0.786, 1, 1.272, 1.618}
+
+// FibRetracement implements the Plotter interface, drawing Fibonacci
+// retracement and extension levels between two anchor points.
+//
+// The price of the level with ratio r is P2 + r*(P1-P2), i.e. ratio 0 is at
+// the second anchor, ratio 1 is at the first anchor, ratios between 0 and 1
+// are retracements of the move from P1 to P2, ratios above 1 extend beyond
+// P1 and negative ratios extend beyond P2.
+type FibRetracement struct {
+	// T1, P1 and T2, P2 are the time and price of the anchor points.
+	T1, P1, T2, P2 float64
+
+	// Levels are the ratios of the levels to draw.
+	Levels []float64
+
+	// LineStyle is the style used to draw the levels.
+	draw.LineStyle
+
+	// ZoneColors are used in turn to shade the zones between adjacent levels.
+	// No zones are shaded if ZoneColors is empty.
+	ZoneColors []color.Color
+
+	// TextStyle is the style of the level labels.
+	TextStyle draw.TextStyle
+
+	// ExtendRight determines if the levels are extended to the right edge
+	// of the data canvas. Otherwise they end at the later anchor point.
+	ExtendRight bool
+
+	// InDataRange determines if the anchors and levels are taken into account
+	// by DataRange. If it is false then the retracement does not change the
+	// range of the axes when added to a plot.
+	InDataRange bool
+
+	// PositiveOnly determines if DataRange ignores levels with non-positive
+	// prices, which cannot be shown on a log scale (plot.LogScale).
+	// Such levels are never drawn on a log scale.
+	PositiveOnly bool
+
+	// Viewport, if not nil, restricts the levels which are drawn and
+	// covered by DataRange to the part within the viewport.
+	Viewport *Viewport
+}
+
+// NewFibRetracement creates a new Fibonacci retracement plotter for the
+// anchor points t1, p1 and t2, p2.
+func NewFibRetracement(t1, p1, t2, p2 float64) (*FibRetracement, error) {
+	if err := plotter.CheckFloats(t1, p1, t2, p2); err != nil {
+		return nil, err
+	}
+
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(8))
+	if err != nil {
+		return nil, err
+	}
+
+	lineStyle := plotter.DefaultLineStyle
+	lineStyle.Color = color.RGBA{R: 128, G: 128, B: 128, A: 255}
+
+	return &FibRetracement{
+		T1:        t1,
+		P1:        p1,
+		T2:        t2,
+		P2:        p2,
+		Levels:    DefaultFibonacciLevels,
+		LineStyle: lineStyle,
+		ZoneColors: []color.Color{
+			color.NRGBA{R: 255, G: 192, B: 128, A: 64},
+			color.NRGBA{R: 128, G: 192, B: 255, A: 64},
+		},
+		TextStyle: draw.TextStyle{
+			Color:  lineStyle.Color,
+			Font:   font,
+			XAlign: draw.XRight,
+			YAlign: draw.YBottom,
+		},
+	}, nil
+}
+
+// NewFibRetracementAuto creates a new Fibonacci retracement plotter which is
+// anchored at the lowest low and the highest high of the bars from index
+// from to index to of data. The earlier of the two extremes is the first anchor.
+func NewFibRetracementAuto(data TOHLCVer, from, to int) (*FibRetracement, error) {
+	if from < 0 || to >= data.Len() || from > to {
+		return nil, fmt.Errorf("custplotter: invalid bar range [%d, %d] for %d bars", from, to, data.Len())
+	}
+
+	var tHigh, tLow float64
+	high := math.Inf(-1)
+	low := math.Inf(1)
+	for i := from; i <= to; i++ {
+		t, _, h, l, _, _ := data.TOHLCV(i)
+		if h > high {
+			tHigh, high = t, h
+		}
+		if l < low {
+			tLow, low = t, l
+		}
+	}
+
+	if tLow <= tHigh {
+		return NewFibRetracement(tLow, low, tHigh, high)
+	}
+	return NewFibRetracement(tHigh, high, tLow, low)
+}
+
+// Price returns the price of the level with the given ratio.
+func (fib *FibRetracement) Price(ratio float64) float64 {
+	return fib.P2 + ratio*(fib.P1-fib.P2)
+}
+
+// span returns the times from which to which the levels are drawn within
+// the viewport and whether they are within the viewport at all. The end is
+// +Inf if the levels are extended to the right and there is no viewport.
+func (fib *FibRetracement) span() (start, end float64, ok bool) {
+	start, end = math.Min(fib.T1, fib.T2), math.Max(fib.T1, fib.T2)
+	if fib.ExtendRight {
+		end = math.Inf(1)
+	}
+	if vp := fib.Viewport; vp != nil {
+		start, end = math.Max(start, vp.From), math.Min(end, vp.To)
+	}
+	return start, end, start <= end
+}
+
+// levelRange returns the range of the prices of the levels.
+func (fib *FibRetracement) levelRange() (ymin, ymax float64) {
+	ymin = math.Inf(1)
+	ymax = math.Inf(-1)
+	for _, ratio := range fib.Levels {
+		price := fib.Price(ratio)
+		if fib.PositiveOnly && price <= 0 {
+			continue
+		}
+		ymin = math.Min(ymin, price)
+		ymax = math.Max(ymax, price)
+	}
+	return
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (fib *FibRetracement) Plot(c draw.Canvas, plt *plot.Plot) {
+	start, end, ok := fib.span()
+	if !ok {
+		return
+	}
+	trX, trY := plt.Transforms(&c)
+
+	xmin := trX(start)
+	xmax := c.Max.X
+	if !fib.ExtendRight {
+		xmax = trX(end)
+	}
+
+	// levels below zero cannot be shown on a log scale
+	var levels []float64
+	for _, ratio := range fib.Levels {
+		if canTransform(plt.Y, fib.Price(ratio)) {
+			levels = append(levels, ratio)
+		}
+	}
+	sort.Float64s(levels)
+
+	if len(fib.ZoneColors) > 0 {
+		for i := 1; i < len(levels); i++ {
+			y0 := trY(fib.Price(levels[i-1]))
+			y1 := trY(fib.Price(levels[i]))
+			zone := c.ClipPolygonXY([]vg.Point{{X: xmin, Y: y0}, {X: xmax, Y: y0}, {X: xmax, Y: y1}, {X: xmin, Y: y1}})
+			c.FillPolygon(fib.ZoneColors[(i-1)%len(fib.ZoneColors)], zone)
+		}
+	}
+
+	for _, ratio := range levels {
+		price := fib.Price(ratio)
+		y := trY(price)
+		line := c.ClipLinesXY([]vg.Point{{X: xmin, Y: y}, {X: xmax, Y: y}})
+		c.StrokeLines(fib.LineStyle, line...)
+
+		if c.Contains(vg.Point{X: xmax, Y: y}) {
+			c.FillText(fib.TextStyle, vg.Point{X: xmax, Y: y}, fmt.Sprintf("%.1f%% %.2f", ratio*100, price))
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+// The range is empty unless InDataRange is set.
+func (fib *FibRetracement) DataRange() (xmin, xmax, ymin, ymax float64) {
+	start, end, ok := fib.span()
+	if !fib.InDataRange || !ok {
+		return math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	}
+
+	if math.IsInf(end, 1) {
+		end = math.Max(fib.T1, fib.T2)
+	}
+	ymin, ymax = fib.levelRange()
+	return start, end, ymin, ymax
+}
+
+// WindowDataRange returns the Y range of the levels if they are
+// drawn within [xmin, xmax] like DataRange.
+func (fib *FibRetracement) WindowDataRange(xmin, xmax float64) (ymin, ymax float64) {
+	start, end, ok := fib.span()
+	if !fib.InDataRange || !ok || end < xmin || start > xmax {
+		return math.Inf(1), math.Inf(-1)
+	}
+	return fib.levelRange()
+}
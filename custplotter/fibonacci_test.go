@@ -0,0 +1,110 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestFibRetracementAuto(t *testing.T) {
+	data := custplotter.TOHLCVs{
+		{T: 1, O: 10, H: 11, L: 8, C: 10, V: 1},
+		{T: 2, O: 10, H: 15, L: 9, C: 14, V: 1},
+		{T: 3, O: 14, H: 20, L: 13, C: 19, V: 1},
+		{T: 4, O: 19, H: 19, L: 16, C: 17, V: 1},
+	}
+
+	fib, err := custplotter.NewFibRetracementAuto(data, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fib.T1 != 1 || fib.P1 != 8 || fib.T2 != 3 || fib.P2 != 20 {
+		t.Errorf("anchors = (%v, %v), (%v, %v), want (1, 8), (3, 20)", fib.T1, fib.P1, fib.T2, fib.P2)
+	}
+	if got, want := fib.Price(0.5), 14.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Price(0.5) = %v, want %v", got, want)
+	}
+
+	xmin, xmax, ymin, ymax := fib.DataRange()
+	if !math.IsInf(xmin, 1) || !math.IsInf(xmax, -1) || !math.IsInf(ymin, 1) || !math.IsInf(ymax, -1) {
+		t.Errorf("DataRange() = %v, %v, %v, %v, want empty range", xmin, xmax, ymin, ymax)
+	}
+
+	fib.InDataRange = true
+	fib.Levels = []float64{0, 1, 1.5}
+	xmin, xmax, ymin, ymax = fib.DataRange()
+	if xmin != 1 || xmax != 3 || ymin != 2 || ymax != 20 {
+		t.Errorf("DataRange() = %v, %v, %v, %v, want 1, 3, 2, 20", xmin, xmax, ymin, ymax)
+	}
+
+	ymin, ymax = fib.WindowDataRange(4, 5)
+	if !math.IsInf(ymin, 1) || !math.IsInf(ymax, -1) {
+		t.Errorf("WindowDataRange(4, 5) = %v, %v, want empty range", ymin, ymax)
+	}
+
+	// the levels start at the viewport and extend to its end
+	fib.ExtendRight = true
+	fib.Viewport, err = custplotter.NewViewport(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xmin, xmax, ymin, ymax = fib.DataRange()
+	if xmin != 2 || xmax != 4 || ymin != 2 || ymax != 20 {
+		t.Errorf("DataRange() = %v, %v, %v, %v, want 2, 4, 2, 20", xmin, xmax, ymin, ymax)
+	}
+	ymin, ymax = fib.WindowDataRange(4, 5)
+	if ymin != 2 || ymax != 20 {
+		t.Errorf("WindowDataRange(4, 5) = %v, %v, want 2, 20", ymin, ymax)
+	}
+
+	fib.ExtendRight = false
+	fib.Viewport.From = 3.5
+	xmin, xmax, ymin, ymax = fib.DataRange()
+	if !math.IsInf(xmin, 1) || !math.IsInf(xmax, -1) || !math.IsInf(ymin, 1) || !math.IsInf(ymax, -1) {
+		t.Errorf("DataRange() = %v, %v, %v, %v, want empty range outside the viewport", xmin, xmax, ymin, ymax)
+	}
+
+	if _, err := custplotter.NewFibRetracementAuto(data, 2, 4); err == nil {
+		t.Error("expected error for invalid bar range")
+	}
+}
+
+func TestNewFibRetracement(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	fib, err := custplotter.NewFibRetracementAuto(testTOHLCVs, 0, len(testTOHLCVs)/2)
+	if err != nil {
+		log.Panic(err)
+	}
+	fib.ExtendRight = true
+
+	p.Add(fib, sticks)
+
+	testFile := "testdata/fibretracement.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
@@ -0,0 +1,158 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"fmt"
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// PriceGap is a price gap between the bar with index Index and its
+// predecessor. A gap up opens above the high of the previous bar, a gap
+// down opens below the low of the previous bar.
+type PriceGap struct {
+	// Index is the index of the bar opening beyond the previous bar.
+	Index int
+
+	// Up determines if the gap is a gap up.
+	Up bool
+
+	// Top and Bottom are the prices of the gap zone.
+	Top, Bottom float64
+
+	// FilledIndex is the index of the bar filling the gap,
+	// i.e. trading back to the previous high or low.
+	// It is -1 if the gap is not filled.
+	FilledIndex int
+}
+
+// Size returns the size of the gap.
+func (g PriceGap) Size() float64 {
+	return g.Top - g.Bottom
+}
+
+// FindGaps returns the price gaps of data in the order of the bars.
+// A gap may be filled by the gap bar itself.
+func FindGaps(data TOHLCVer) []PriceGap {
+	var gaps []PriceGap
+	for i := 1; i < data.Len(); i++ {
+		_, _, prevH, prevL, _, _ := data.TOHLCV(i - 1)
+		_, o, _, _, _, _ := data.TOHLCV(i)
+
+		var g PriceGap
+		switch {
+		case o > prevH:
+			g = PriceGap{Index: i, Up: true, Top: o, Bottom: prevH}
+		case o < prevL:
+			g = PriceGap{Index: i, Up: false, Top: prevL, Bottom: o}
+		default:
+			continue
+		}
+
+		g.FilledIndex = -1
+		for j := i; j < data.Len(); j++ {
+			_, _, h, l, _, _ := data.TOHLCV(j)
+			if (g.Up && l <= g.Bottom) || (!g.Up && h >= g.Top) {
+				g.FilledIndex = j
+				break
+			}
+		}
+		gaps = append(gaps, g)
+	}
+	return gaps
+}
+
+// Gaps implements the Plotter interface, shading the zone of each gap
+// from the gap bar to the bar filling the gap. The zones of unfilled gaps
+// extend to the right edge of the data canvas.
+type Gaps struct {
+	TOHLCVs
+
+	Gaps []PriceGap
+
+	// ColorUp is the fill color of the zones of gaps up.
+	ColorUp color.Color
+
+	// ColorDown is the fill color of the zones of gaps down.
+	ColorDown color.Color
+
+	// Labels determines if the zones are labeled with the gap size.
+	Labels bool
+
+	// TextStyle is the style of the labels.
+	TextStyle draw.TextStyle
+
+	// Format is the format of the gap size.
+	Format string
+
+	// Viewport, if not nil, restricts the gaps which are drawn
+	// to the ones opened by bars within the viewport.
+	Viewport *Viewport
+}
+
+// NewGaps creates a new gap plotter for the gaps found in the given data.
+func NewGaps(TOHLCV TOHLCVer) (*Gaps, error) {
+	cpy, err := CopyTOHLCVs(TOHLCV)
+	if err != nil {
+		return nil, err
+	}
+
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(6))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Gaps{
+		TOHLCVs:   cpy,
+		Gaps:      FindGaps(cpy),
+		ColorUp:   color.NRGBA{R: 0, G: 160, B: 0, A: 48},
+		ColorDown: color.NRGBA{R: 224, G: 0, B: 0, A: 48},
+		TextStyle: draw.TextStyle{
+			Color:  color.Black,
+			Font:   font,
+			YAlign: draw.YCenter,
+		},
+		Format: "%.2f",
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (gp *Gaps) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	for _, g := range gp.Gaps {
+		if g.Index < 0 || g.Index >= len(gp.TOHLCVs) || !canTransform(plt.Y, g.Bottom) {
+			continue
+		}
+		if !gp.Viewport.Contains(gp.TOHLCVs[g.Index].T) {
+			continue
+		}
+
+		xmin := trX(gp.TOHLCVs[g.Index].T)
+		xmax := c.Max.X
+		if g.FilledIndex >= 0 && g.FilledIndex < len(gp.TOHLCVs) {
+			xmax = trX(gp.TOHLCVs[g.FilledIndex].T)
+		}
+		ymin, ymax := trY(g.Bottom), trY(g.Top)
+
+		clr := gp.ColorDown
+		if g.Up {
+			clr = gp.ColorUp
+		}
+		zone := c.ClipPolygonXY([]vg.Point{{X: xmin, Y: ymin}, {X: xmax, Y: ymin}, {X: xmax, Y: ymax}, {X: xmin, Y: ymax}})
+		c.FillPolygon(clr, zone)
+
+		if gp.Labels {
+			pt := vg.Point{X: xmin, Y: (ymin + ymax) / 2}
+			if c.Contains(pt) {
+				c.FillText(gp.TextStyle, pt, fmt.Sprintf(gp.Format, g.Size()))
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+// gapTestData has a gap up at bar 2 filled by bar 5, a gap down
+// at bar 6 filled by the gap bar itself and an unfilled gap up at bar 8.
+var gapTestData = custplotter.TOHLCVs{
+	{T: 0, O: 100, H: 101, L: 99, C: 100.5},
+	{T: 60, O: 100.5, H: 102, L: 100, C: 101.5},
+	{T: 120, O: 103, H: 104, L: 102.5, C: 103.5},
+	{T: 180, O: 103.5, H: 105, L: 103, C: 104.5},
+	{T: 240, O: 104.5, H: 105, L: 103.5, C: 103.8},
+	{T: 300, O: 103.8, H: 104, L: 101.5, C: 102},
+	{T: 360, O: 101, H: 101.8, L: 100.5, C: 101.5},
+	{T: 420, O: 101.5, H: 102.5, L: 101, C: 102.2},
+	{T: 480, O: 103.5, H: 104.5, L: 103.2, C: 104.2},
+	{T: 540, O: 104.2, H: 105.5, L: 104, C: 105.2},
+}
+
+func TestFindGaps(t *testing.T) {
+	got := custplotter.FindGaps(gapTestData)
+	want := []custplotter.PriceGap{
+		{Index: 2, Up: true, Top: 103, Bottom: 102, FilledIndex: 5},
+		{Index: 6, Up: false, Top: 101.5, Bottom: 101, FilledIndex: 6},
+		{Index: 8, Up: true, Top: 103.5, Bottom: 102.5, FilledIndex: -1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindGaps() = %v, want %v", got, want)
+	}
+}
+
+func TestNewGaps(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	gaps, err := custplotter.NewGaps(gapTestData)
+	if err != nil {
+		log.Panic(err)
+	}
+	gaps.Labels = true
+
+	sticks, err := custplotter.NewCandlesticks(gapTestData)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(gaps, sticks)
+
+	testFile := "testdata/gaps.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
@@ -0,0 +1,235 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"errors"
+	"math"
+)
+
+// errPeriod is returned by the indicator constructors for non-positive periods.
+var errPeriod = errors.New("custplotter: indicator period must be positive")
+
+// Indicator is implemented by technical indicators that are computed
+// incrementally from a stream of time, open, high, low, close, volume tuples.
+// Appending a bar or replacing the last, still-forming bar costs O(1),
+// so live charts do not have to recompute the whole series on every update.
+type Indicator interface {
+	// Append adds a new bar and returns the indicator value for it.
+	Append(t, o, h, l, c, v float64) float64
+
+	// Update replaces the last bar, e.g. a bar which is still forming,
+	// and returns the new indicator value for it. Update behaves like
+	// Append if no bar has been added yet.
+	Update(t, o, h, l, c, v float64) float64
+
+	// Value returns the indicator value of the last bar. It is NaN until
+	// enough bars have been added.
+	Value() float64
+
+	// Len returns the number of bars added so far.
+	Len() int
+
+	// Snapshot returns an independent copy of the current state which
+	// can be used to continue the computation from this point later.
+	Snapshot() Indicator
+}
+
+// IndicatorValues feeds all tuples of data into ind and returns the
+// indicator value for each of them.
+func IndicatorValues(ind Indicator, data TOHLCVer) []float64 {
+	values := make([]float64, data.Len())
+	for i := range values {
+		values[i] = ind.Append(data.TOHLCV(i))
+	}
+	return values
+}
+
+// SMA is the simple moving average of the close prices.
+type SMA struct {
+	period int
+	closes []float64 // ring buffer of the last period closes
+	sum    float64
+	n      int
+}
+
+// NewSMA creates a simple moving average over period bars.
+func NewSMA(period int) (*SMA, error) {
+	if period < 1 {
+		return nil, errPeriod
+	}
+	return &SMA{period: period, closes: make([]float64, period)}, nil
+}
+
+// Append implements the Append method of the Indicator interface.
+func (sma *SMA) Append(t, o, h, l, c, v float64) float64 {
+	i := sma.n % sma.period
+	sma.sum += c - sma.closes[i]
+	sma.closes[i] = c
+	sma.n++
+	return sma.Value()
+}
+
+// Update implements the Update method of the Indicator interface.
+func (sma *SMA) Update(t, o, h, l, c, v float64) float64 {
+	if sma.n == 0 {
+		return sma.Append(t, o, h, l, c, v)
+	}
+	i := (sma.n - 1) % sma.period
+	sma.sum += c - sma.closes[i]
+	sma.closes[i] = c
+	return sma.Value()
+}
+
+// Value implements the Value method of the Indicator interface.
+func (sma *SMA) Value() float64 {
+	if sma.n < sma.period {
+		return math.NaN()
+	}
+	return sma.sum / float64(sma.period)
+}
+
+// Len implements the Len method of the Indicator interface.
+func (sma *SMA) Len() int {
+	return sma.n
+}
+
+// Snapshot implements the Snapshot method of the Indicator interface.
+func (sma *SMA) Snapshot() Indicator {
+	cpy := *sma
+	cpy.closes = append([]float64(nil), sma.closes...)
+	return &cpy
+}
+
+// EMA is the exponential moving average of the close prices.
+// It is seeded with the simple moving average of the first period closes.
+type EMA struct {
+	period int
+	alpha  float64
+	n      int
+	sum    float64 // sum of the closes while seeding
+	last   float64 // close of the last bar
+	prev   float64 // value before the last bar
+	value  float64
+}
+
+// NewEMA creates an exponential moving average over period bars.
+func NewEMA(period int) (*EMA, error) {
+	if period < 1 {
+		return nil, errPeriod
+	}
+	return &EMA{period: period, alpha: 2 / float64(period+1), prev: math.NaN(), value: math.NaN()}, nil
+}
+
+// Append implements the Append method of the Indicator interface.
+func (ema *EMA) Append(t, o, h, l, c, v float64) float64 {
+	ema.n++
+	ema.prev = ema.value
+	ema.last = 0
+	return ema.Update(t, o, h, l, c, v)
+}
+
+// Update implements the Update method of the Indicator interface.
+func (ema *EMA) Update(t, o, h, l, c, v float64) float64 {
+	if ema.n == 0 {
+		return ema.Append(t, o, h, l, c, v)
+	}
+	switch {
+	case ema.n <= ema.period:
+		ema.sum += c - ema.last
+		if ema.n == ema.period {
+			ema.value = ema.sum / float64(ema.period)
+		}
+	default:
+		ema.value = ema.alpha*c + (1-ema.alpha)*ema.prev
+	}
+	ema.last = c
+	return ema.value
+}
+
+// Value implements the Value method of the Indicator interface.
+func (ema *EMA) Value() float64 {
+	return ema.value
+}
+
+// Len implements the Len method of the Indicator interface.
+func (ema *EMA) Len() int {
+	return ema.n
+}
+
+// Snapshot implements the Snapshot method of the Indicator interface.
+func (ema *EMA) Snapshot() Indicator {
+	cpy := *ema
+	return &cpy
+}
+
+// ATR is Wilder's average true range. It is seeded with the simple
+// average of the first period true ranges.
+type ATR struct {
+	period    int
+	n         int
+	sum       float64 // sum of the true ranges while seeding
+	lastTR    float64 // true range of the last bar
+	prevClose float64 // close of the bar before the last bar
+	lastClose float64
+	prev      float64 // value before the last bar
+	value     float64
+}
+
+// NewATR creates an average true range over period bars.
+func NewATR(period int) (*ATR, error) {
+	if period < 1 {
+		return nil, errPeriod
+	}
+	return &ATR{period: period, prev: math.NaN(), value: math.NaN()}, nil
+}
+
+// Append implements the Append method of the Indicator interface.
+func (atr *ATR) Append(t, o, h, l, c, v float64) float64 {
+	atr.n++
+	atr.prevClose = atr.lastClose
+	atr.prev = atr.value
+	atr.lastTR = 0
+	return atr.Update(t, o, h, l, c, v)
+}
+
+// Update implements the Update method of the Indicator interface.
+func (atr *ATR) Update(t, o, h, l, c, v float64) float64 {
+	if atr.n == 0 {
+		return atr.Append(t, o, h, l, c, v)
+	}
+	tr := h - l
+	if atr.n > 1 {
+		tr = math.Max(tr, math.Max(math.Abs(h-atr.prevClose), math.Abs(l-atr.prevClose)))
+	}
+	switch {
+	case atr.n <= atr.period:
+		atr.sum += tr - atr.lastTR
+		if atr.n == atr.period {
+			atr.value = atr.sum / float64(atr.period)
+		}
+	default:
+		atr.value = (atr.prev*float64(atr.period-1) + tr) / float64(atr.period)
+	}
+	atr.lastTR = tr
+	atr.lastClose = c
+	return atr.value
+}
+
+// Value implements the Value method of the Indicator interface.
+func (atr *ATR) Value() float64 {
+	return atr.value
+}
+
+// Len implements the Len method of the Indicator interface.
+func (atr *ATR) Len() int {
+	return atr.n
+}
+
+// Snapshot implements the Snapshot method of the Indicator interface.
+func (atr *ATR) Snapshot() Indicator {
+	cpy := *atr
+	return &cpy
+}
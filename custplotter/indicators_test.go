@@ -0,0 +1,130 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+)
+
+func newIndicators(t *testing.T, period int) map[string]func() custplotter.Indicator {
+	return map[string]func() custplotter.Indicator{
+		"SMA": func() custplotter.Indicator {
+			ind, err := custplotter.NewSMA(period)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return ind
+		},
+		"EMA": func() custplotter.Indicator {
+			ind, err := custplotter.NewEMA(period)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return ind
+		},
+		"ATR": func() custplotter.Indicator {
+			ind, err := custplotter.NewATR(period)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return ind
+		},
+	}
+}
+
+func equalValues(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestSMAValues(t *testing.T) {
+	data := internal.CreateTOHLCVTestData()
+	period := 5
+
+	sma, err := custplotter.NewSMA(period)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := custplotter.IndicatorValues(sma, data)
+	for i := range data {
+		want := math.NaN()
+		if i >= period-1 {
+			want = 0
+			for j := i - period + 1; j <= i; j++ {
+				want += data[j].C
+			}
+			want /= float64(period)
+		}
+		if !equalValues(values[i], want) {
+			t.Errorf("SMA[%d] = %v, want %v", i, values[i], want)
+		}
+	}
+}
+
+func TestIndicatorUpdate(t *testing.T) {
+	data := internal.CreateTOHLCVTestData()
+
+	for name, newIndicator := range newIndicators(t, 4) {
+		want := custplotter.IndicatorValues(newIndicator(), data)
+
+		ind := newIndicator()
+		for i, bar := range data {
+			// simulate a forming bar which is updated twice before it is final
+			ind.Append(bar.T, bar.O, bar.O, bar.O, bar.O, 0)
+			ind.Update(bar.T, bar.O, bar.H, bar.L, (bar.O+bar.C)/2, bar.V/2)
+			if got := ind.Update(bar.T, bar.O, bar.H, bar.L, bar.C, bar.V); !equalValues(got, want[i]) {
+				t.Errorf("%s: Update value %d = %v, want %v", name, i, got, want[i])
+			}
+		}
+		if ind.Len() != len(data) {
+			t.Errorf("%s: Len() = %d, want %d", name, ind.Len(), len(data))
+		}
+	}
+}
+
+func TestIndicatorSnapshot(t *testing.T) {
+	data := internal.CreateTOHLCVTestData()
+	half := len(data) / 2
+
+	for name, newIndicator := range newIndicators(t, 3) {
+		want := custplotter.IndicatorValues(newIndicator(), data)
+
+		ind := newIndicator()
+		custplotter.IndicatorValues(ind, data[:half])
+		snapshot := ind.Snapshot()
+
+		// advancing the original must not change the snapshot
+		custplotter.IndicatorValues(ind, data[half:])
+		if !equalValues(snapshot.Value(), want[half-1]) {
+			t.Errorf("%s: snapshot value = %v, want %v", name, snapshot.Value(), want[half-1])
+		}
+
+		got := custplotter.IndicatorValues(snapshot, data[half:])
+		for i := range got {
+			if !equalValues(got[i], want[half+i]) {
+				t.Errorf("%s: value %d after snapshot = %v, want %v", name, half+i, got[i], want[half+i])
+			}
+		}
+	}
+}
+
+func TestIndicatorPeriod(t *testing.T) {
+	if _, err := custplotter.NewSMA(0); err == nil {
+		t.Error("expected error for SMA period 0")
+	}
+	if _, err := custplotter.NewEMA(-1); err == nil {
+		t.Error("expected error for EMA period -1")
+	}
+	if _, err := custplotter.NewATR(0); err == nil {
+		t.Error("expected error for ATR period 0")
+	}
+}
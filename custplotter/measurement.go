@@ -0,0 +1,198 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Measurement implements the Plotter interface, drawing a shaded rectangle
+// between two points given as time and price. The rectangle is labeled
+// with the price change, the percent change, the number of bars and the
+// elapsed time from the first to the second point.
+type Measurement struct {
+	// T1, P1 and T2, P2 are the time and price of the two points.
+	T1, P1, T2, P2 float64
+
+	// Bars is the number of bars from the first to the second point.
+	// The number of bars is not labeled if Bars is negative.
+	Bars int
+
+	// ColorUp is the fill color of the rectangle if P2 >= P1.
+	ColorUp color.Color
+
+	// ColorDown is the fill color of the rectangle if P2 < P1.
+	ColorDown color.Color
+
+	// LineStyle is the style used to draw the diagonal from
+	// the first to the second point.
+	draw.LineStyle
+
+	// TextStyle is the style of the label.
+	TextStyle draw.TextStyle
+
+	// Format is the format of the price change.
+	Format string
+
+	// Gap is the distance between the rectangle and the label.
+	Gap vg.Length
+
+	// PositiveOnly determines if DataRange is empty if a price is
+	// not positive, because the measurement cannot be shown on a log
+	// scale (plot.LogScale) then. It is never drawn on a log scale.
+	PositiveOnly bool
+
+	// Viewport, if not nil, hides the measurement
+	// unless both ends are within the viewport.
+	Viewport *Viewport
+}
+
+// visible returns whether both ends of the measurement are within the viewport.
+func (m *Measurement) visible() bool {
+	return m.Viewport.Contains(m.T1) && m.Viewport.Contains(m.T2)
+}
+
+// NewMeasurement creates a new measurement plotter for the points
+// t1, p1 and t2, p2. The number of bars is unknown and not labeled.
+func NewMeasurement(t1, p1, t2, p2 float64) (*Measurement, error) {
+	if err := plotter.CheckFloats(t1, p1, t2, p2); err != nil {
+		return nil, err
+	}
+
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(6))
+	if err != nil {
+		return nil, err
+	}
+
+	lineStyle := plotter.DefaultLineStyle
+	lineStyle.Width = vg.Points(0.5)
+	lineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+
+	return &Measurement{
+		T1:        t1,
+		P1:        p1,
+		T2:        t2,
+		P2:        p2,
+		Bars:      -1,
+		ColorUp:   color.NRGBA{R: 0, G: 128, B: 255, A: 48},
+		ColorDown: color.NRGBA{R: 255, G: 64, B: 0, A: 48},
+		LineStyle: lineStyle,
+		TextStyle: draw.TextStyle{
+			Color:  color.Black,
+			Font:   font,
+			XAlign: draw.XCenter,
+		},
+		Format: "%+.2f",
+		Gap:    vg.Points(2),
+	}, nil
+}
+
+// NewMeasurementBars creates a new measurement plotter from the close
+// of the bar with index from to the close of the bar with index to of data.
+func NewMeasurementBars(data TOHLCVer, from, to int) (*Measurement, error) {
+	if from < 0 || to < 0 || from >= data.Len() || to >= data.Len() {
+		return nil, fmt.Errorf("custplotter: invalid bar indices %d, %d for %d bars", from, to, data.Len())
+	}
+
+	t1, _, _, _, c1, _ := data.TOHLCV(from)
+	t2, _, _, _, c2, _ := data.TOHLCV(to)
+	m, err := NewMeasurement(t1, c1, t2, c2)
+	if err != nil {
+		return nil, err
+	}
+	m.Bars = to - from
+	if m.Bars < 0 {
+		m.Bars = -m.Bars
+	}
+	return m, nil
+}
+
+// Label returns the label of the measurement.
+func (m *Measurement) Label() string {
+	change := fmt.Sprintf(m.Format, m.P2-m.P1)
+	if m.P1 != 0 {
+		change += fmt.Sprintf(" (%+.2f%%)", (m.P2-m.P1)/m.P1*100)
+	}
+
+	elapsed := time.Duration(math.Abs(m.T2-m.T1) * float64(time.Second)).String()
+	if m.Bars >= 0 {
+		return fmt.Sprintf("%s\n%d bars, %s", change, m.Bars, elapsed)
+	}
+	return change + "\n" + elapsed
+}
+
+// labelAbove returns whether the label is placed above the rectangle.
+func (m *Measurement) labelAbove() bool {
+	return m.P2 >= m.P1
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (m *Measurement) Plot(c draw.Canvas, plt *plot.Plot) {
+	if !m.visible() || !canTransform(plt.Y, m.P1) || !canTransform(plt.Y, m.P2) {
+		return
+	}
+	trX, trY := plt.Transforms(&c)
+
+	x1, y1 := trX(m.T1), trY(m.P1)
+	x2, y2 := trX(m.T2), trY(m.P2)
+
+	clr := m.ColorUp
+	if !m.labelAbove() {
+		clr = m.ColorDown
+	}
+	rect := c.ClipPolygonXY([]vg.Point{{X: x1, Y: y1}, {X: x2, Y: y1}, {X: x2, Y: y2}, {X: x1, Y: y2}})
+	c.FillPolygon(clr, rect)
+	c.StrokeLines(m.LineStyle, c.ClipLinesXY([]vg.Point{{X: x1, Y: y1}, {X: x2, Y: y2}})...)
+
+	// The label is drawn into the space reserved by GlyphBoxes.
+	txt := m.Label()
+	pt := vg.Point{X: (x1 + x2) / 2, Y: y2 + m.Gap}
+	if !m.labelAbove() {
+		pt.Y = y2 - m.Gap - m.TextStyle.Height(txt)
+	}
+	c.FillText(m.TextStyle, pt, txt)
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (m *Measurement) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if !m.visible() || m.PositiveOnly && (m.P1 <= 0 || m.P2 <= 0) {
+		return math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	}
+	return math.Min(m.T1, m.T2), math.Max(m.T1, m.T2), math.Min(m.P1, m.P2), math.Max(m.P1, m.P2)
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+func (m *Measurement) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if !m.visible() || !canTransform(plt.Y, m.P1) || !canTransform(plt.Y, m.P2) {
+		return nil
+	}
+	txt := m.Label()
+	w := m.TextStyle.Width(txt)
+	h := m.TextStyle.Height(txt)
+
+	box := plot.GlyphBox{
+		X: plt.X.Norm((m.T1 + m.T2) / 2),
+		Y: plt.Y.Norm(m.P2),
+		Rectangle: vg.Rectangle{
+			Min: vg.Point{X: -w / 2, Y: m.Gap},
+			Max: vg.Point{X: w / 2, Y: m.Gap + h},
+		},
+	}
+	if !m.labelAbove() {
+		box.Rectangle.Min.Y = -m.Gap - h
+		box.Rectangle.Max.Y = -m.Gap
+	}
+	return []plot.GlyphBox{box}
+}
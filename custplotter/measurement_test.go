@@ -0,0 +1,65 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestMeasurementLabel(t *testing.T) {
+	m, err := custplotter.NewMeasurement(0, 100, 5400, 98)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m.Label(), "-2.00 (-2.00%)\n1h30m0s"; got != want {
+		t.Errorf("Label() = %q, want %q", got, want)
+	}
+
+	m.Bars = 90
+	if got, want := m.Label(), "-2.00 (-2.00%)\n90 bars, 1h30m0s"; got != want {
+		t.Errorf("Label() = %q, want %q", got, want)
+	}
+}
+
+func TestNewMeasurementBars(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	up, err := custplotter.NewMeasurementBars(testTOHLCVs, 1, 10)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	down, err := custplotter.NewMeasurementBars(testTOHLCVs, 12, 19)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(up, down, sticks)
+
+	testFile := "testdata/measurement.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
@@ -34,6 +34,15 @@ type OHLCBars struct {
 	// CapWidth is the width of the caps drawn at the top
 	// of each error bar.
 	TickWidth vg.Length
+
+	// PositiveOnly determines if DataRange ignores bars with non-positive
+	// prices, which cannot be shown on a log scale (plot.LogScale).
+	// Such bars are never drawn on a log scale.
+	PositiveOnly bool
+
+	// Viewport, if not nil, restricts the bars which are drawn and
+	// covered by DataRange to the bars within the viewport.
+	Viewport *Viewport
 }
 
 // NewBars creates as new bar plotter for
@@ -59,6 +68,13 @@ func (bars *OHLCBars) Plot(c draw.Canvas, plt *plot.Plot) {
 	lineStyle := bars.LineStyle
 
 	for _, TOHLCV := range bars.TOHLCVs {
+		if !bars.Viewport.Contains(TOHLCV.T) {
+			continue
+		}
+		if !canTransform(plt.Y, TOHLCV.L) {
+			continue
+		}
+
 		if TOHLCV.C >= TOHLCV.O {
 			lineStyle.Color = bars.ColorUp
 		} else {
@@ -95,6 +111,12 @@ func (bars *OHLCBars) DataRange() (xmin, xmax, ymin, ymax float64) {
 	ymin = math.Inf(1)
 	ymax = math.Inf(-1)
 	for _, TOHLCV := range bars.TOHLCVs {
+		if !bars.Viewport.Contains(TOHLCV.T) {
+			continue
+		}
+		if bars.PositiveOnly && TOHLCV.L <= 0 {
+			continue
+		}
 		xmin = math.Min(xmin, TOHLCV.T)
 		xmax = math.Max(xmax, TOHLCV.T)
 		ymin = math.Min(ymin, TOHLCV.L)
@@ -103,6 +125,24 @@ func (bars *OHLCBars) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return
 }
 
+// WindowDataRange returns the Y range of the bars with T in [xmin, xmax]
+// like DataRange, e.g. to fit the Y axis to the bars within a window.
+func (bars *OHLCBars) WindowDataRange(xmin, xmax float64) (ymin, ymax float64) {
+	ymin = math.Inf(1)
+	ymax = math.Inf(-1)
+	for _, TOHLCV := range bars.TOHLCVs {
+		if TOHLCV.T < xmin || TOHLCV.T > xmax || !bars.Viewport.Contains(TOHLCV.T) {
+			continue
+		}
+		if bars.PositiveOnly && TOHLCV.L <= 0 {
+			continue
+		}
+		ymin = math.Min(ymin, TOHLCV.L)
+		ymax = math.Max(ymax, TOHLCV.H)
+	}
+	return
+}
+
 // GlyphBoxes implements the GlyphBoxes method
 // of the plot.GlyphBoxer interface.
 // We just return 2 glyph boxes at xmin, ymin and xmax, ymax
@@ -128,3 +168,38 @@ func (bars *OHLCBars) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 
 	return boxes
 }
+
+// Thumbnail implements the Thumbnail method
+// of the plot.Thumbnailer interface.
+// It draws a miniature up bar next to a down bar.
+func (bars *OHLCBars) Thumbnail(c *draw.Canvas) {
+	w := c.Max.X - c.Min.X
+	h := c.Max.Y - c.Min.Y
+	tw := bars.TickWidth
+	if tw > w/6 {
+		tw = w / 6
+	}
+
+	// open, high, low and close relative to the height
+	ohlcs := []struct {
+		x, o, h, l, c float64
+		color         color.Color
+	}{
+		{x: 1.0 / 3, o: 0.25, h: 0.8, l: 0.1, c: 0.65, color: bars.ColorUp},
+		{x: 2.0 / 3, o: 0.75, h: 0.9, l: 0.2, c: 0.35, color: bars.ColorDown},
+	}
+
+	lineStyle := bars.LineStyle
+	for _, bar := range ohlcs {
+		lineStyle.Color = bar.color
+		x := c.Min.X + vg.Length(bar.x)*w
+		yo := c.Min.Y + vg.Length(bar.o)*h
+		yh := c.Min.Y + vg.Length(bar.h)*h
+		yl := c.Min.Y + vg.Length(bar.l)*h
+		yc := c.Min.Y + vg.Length(bar.c)*h
+
+		c.StrokeLine2(lineStyle, x, yl, x, yh)
+		c.StrokeLine2(lineStyle, x, yo, x-tw, yo)
+		c.StrokeLine2(lineStyle, x, yc, x+tw, yc)
+	}
+}
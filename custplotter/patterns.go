@@ -0,0 +1,348 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// PatternDirection is the expected price direction after a pattern.
+type PatternDirection int
+
+const (
+	// Neutral patterns indicate indecision.
+	Neutral PatternDirection = iota
+	// Bullish patterns indicate rising prices.
+	Bullish
+	// Bearish patterns indicate falling prices.
+	Bearish
+)
+
+// Pattern is a candlestick pattern consisting of one or more bars.
+type Pattern struct {
+	// Name is the name of the pattern.
+	Name string
+
+	// Direction is the price direction the pattern indicates.
+	Direction PatternDirection
+
+	// Bars is the number of bars of the pattern.
+	Bars int
+
+	// Match reports if the bars form the pattern.
+	// len(bars) is always equal to Bars.
+	Match func(bars TOHLCVs) bool
+}
+
+// PatternMatch is an occurrence of a pattern.
+type PatternMatch struct {
+	// Index is the index of the last bar of the pattern.
+	Index int
+
+	// Name, Direction and Bars are copied from the matching pattern.
+	Name      string
+	Direction PatternDirection
+	Bars      int
+}
+
+// body returns the height of the body of bar i.
+func body(b TOHLCVs, i int) float64 { return math.Abs(b[i].C - b[i].O) }
+
+// bodyTop returns the upper end of the body of bar i.
+func bodyTop(b TOHLCVs, i int) float64 { return math.Max(b[i].O, b[i].C) }
+
+// bodyBottom returns the lower end of the body of bar i.
+func bodyBottom(b TOHLCVs, i int) float64 { return math.Min(b[i].O, b[i].C) }
+
+// isUp reports if bar i closes above its open.
+func isUp(b TOHLCVs, i int) bool { return b[i].C > b[i].O }
+
+// isDown reports if bar i closes below its open.
+func isDown(b TOHLCVs, i int) bool { return b[i].C < b[i].O }
+
+// isLong reports if the body of bar i covers at least half of its range.
+func isLong(b TOHLCVs, i int) bool {
+	return b[i].H > b[i].L && body(b, i) >= (b[i].H-b[i].L)/2
+}
+
+// The predefined candlestick patterns.
+var (
+	Doji = Pattern{Name: "Doji", Direction: Neutral, Bars: 1, Match: func(b TOHLCVs) bool {
+		return b[0].H > b[0].L && body(b, 0) <= 0.1*(b[0].H-b[0].L)
+	}}
+
+	Hammer = Pattern{Name: "Hammer", Direction: Bullish, Bars: 2, Match: func(b TOHLCVs) bool {
+		r := b[1].H - b[1].L
+		return body(b, 1) > 0.1*r && bodyBottom(b, 1)-b[1].L >= 2*body(b, 1) &&
+			b[1].H-bodyTop(b, 1) <= 0.1*r && b[0].C > bodyTop(b, 1)
+	}}
+
+	ShootingStar = Pattern{Name: "Shooting Star", Direction: Bearish, Bars: 2, Match: func(b TOHLCVs) bool {
+		r := b[1].H - b[1].L
+		return body(b, 1) > 0.1*r && b[1].H-bodyTop(b, 1) >= 2*body(b, 1) &&
+			bodyBottom(b, 1)-b[1].L <= 0.1*r && b[0].C < bodyBottom(b, 1)
+	}}
+
+	BullishEngulfing = Pattern{Name: "Bullish Engulfing", Direction: Bullish, Bars: 2, Match: func(b TOHLCVs) bool {
+		return isDown(b, 0) && isUp(b, 1) && b[1].O <= b[0].C && b[1].C >= b[0].O && body(b, 1) > body(b, 0)
+	}}
+
+	BearishEngulfing = Pattern{Name: "Bearish Engulfing", Direction: Bearish, Bars: 2, Match: func(b TOHLCVs) bool {
+		return isUp(b, 0) && isDown(b, 1) && b[1].O >= b[0].C && b[1].C <= b[0].O && body(b, 1) > body(b, 0)
+	}}
+
+	BullishHarami = Pattern{Name: "Bullish Harami", Direction: Bullish, Bars: 2, Match: func(b TOHLCVs) bool {
+		return isDown(b, 0) && isLong(b, 0) && isUp(b, 1) && b[1].O > b[0].C && b[1].C < b[0].O
+	}}
+
+	BearishHarami = Pattern{Name: "Bearish Harami", Direction: Bearish, Bars: 2, Match: func(b TOHLCVs) bool {
+		return isUp(b, 0) && isLong(b, 0) && isDown(b, 1) && b[1].O < b[0].C && b[1].C > b[0].O
+	}}
+
+	MorningStar = Pattern{Name: "Morning Star", Direction: Bullish, Bars: 3, Match: func(b TOHLCVs) bool {
+		return isDown(b, 0) && isLong(b, 0) && body(b, 1) <= 0.3*body(b, 0) && bodyTop(b, 1) < b[0].C &&
+			isUp(b, 2) && b[2].C > (b[0].O+b[0].C)/2
+	}}
+
+	EveningStar = Pattern{Name: "Evening Star", Direction: Bearish, Bars: 3, Match: func(b TOHLCVs) bool {
+		return isUp(b, 0) && isLong(b, 0) && body(b, 1) <= 0.3*body(b, 0) && bodyBottom(b, 1) > b[0].C &&
+			isDown(b, 2) && b[2].C < (b[0].O+b[0].C)/2
+	}}
+
+	ThreeWhiteSoldiers = Pattern{Name: "Three White Soldiers", Direction: Bullish, Bars: 3, Match: func(b TOHLCVs) bool {
+		for i := range b {
+			if !isUp(b, i) || !isLong(b, i) {
+				return false
+			}
+			if i > 0 && (b[i].C <= b[i-1].C || b[i].O < b[i-1].O || b[i].O > b[i-1].C) {
+				return false
+			}
+		}
+		return true
+	}}
+
+	ThreeBlackCrows = Pattern{Name: "Three Black Crows", Direction: Bearish, Bars: 3, Match: func(b TOHLCVs) bool {
+		for i := range b {
+			if !isDown(b, i) || !isLong(b, i) {
+				return false
+			}
+			if i > 0 && (b[i].C >= b[i-1].C || b[i].O > b[i-1].O || b[i].O < b[i-1].C) {
+				return false
+			}
+		}
+		return true
+	}}
+)
+
+// DefaultPatterns are the patterns FindPatterns looks for if no patterns are given.
+var DefaultPatterns = []Pattern{
+	Doji, Hammer, ShootingStar,
+	BullishEngulfing, BearishEngulfing, BullishHarami, BearishHarami,
+	MorningStar, EveningStar, ThreeWhiteSoldiers, ThreeBlackCrows,
+}
+
+// FindPatterns returns all occurrences of the given patterns in data ordered
+// by bar index. DefaultPatterns are used if no patterns are given.
+func FindPatterns(data TOHLCVer, patterns ...Pattern) ([]PatternMatch, error) {
+	cpy, err := CopyTOHLCVs(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(patterns) == 0 {
+		patterns = DefaultPatterns
+	}
+
+	var matches []PatternMatch
+	for i := range cpy {
+		for _, p := range patterns {
+			if i+1 < p.Bars || !p.Match(cpy[i+1-p.Bars:i+1]) {
+				continue
+			}
+			matches = append(matches, PatternMatch{Index: i, Name: p.Name, Direction: p.Direction, Bars: p.Bars})
+		}
+	}
+	return matches, nil
+}
+
+// triangleDownGlyph is a glyph that draws a filled triangle
+// pointing down.
+type triangleDownGlyph struct{}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (triangleDownGlyph) DrawGlyph(c *draw.Canvas, sty draw.GlyphStyle, pt vg.Point) {
+	c.SetLineStyle(draw.LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	r := sty.Radius + (sty.Radius-sty.Radius*vg.Length(math.Sin(math.Pi/6)))/2
+	var p vg.Path
+	p.Move(vg.Point{X: pt.X, Y: pt.Y - r})
+	p.Line(vg.Point{X: pt.X - r*vg.Length(math.Cos(math.Pi/6)), Y: pt.Y + r*vg.Length(math.Sin(math.Pi/6))})
+	p.Line(vg.Point{X: pt.X + r*vg.Length(math.Cos(math.Pi/6)), Y: pt.Y + r*vg.Length(math.Sin(math.Pi/6))})
+	p.Close()
+	c.Fill(p)
+}
+
+// PatternMarkers implements the Plotter interface, drawing a labeled
+// marker for each pattern match. Bullish patterns are marked below
+// the low of the last bar of the pattern, all others above its high.
+type PatternMarkers struct {
+	TOHLCVs
+
+	Matches []PatternMatch
+
+	// ColorBullish, ColorBearish and ColorNeutral are the colors of the
+	// markers of bullish, bearish and neutral patterns.
+	ColorBullish, ColorBearish, ColorNeutral color.Color
+
+	// Radius is the radius of the markers.
+	Radius vg.Length
+
+	// Gap is the distance between a marker and its bar.
+	Gap vg.Length
+
+	// TextStyle is the style of the labels.
+	TextStyle draw.TextStyle
+
+	// Viewport, if not nil, restricts the markers which are
+	// drawn to the ones of bars within the viewport.
+	Viewport *Viewport
+}
+
+// NewPatternMarkers creates a new pattern marker plotter
+// for the given data and pattern matches.
+func NewPatternMarkers(TOHLCV TOHLCVer, matches []PatternMatch) (*PatternMarkers, error) {
+	cpy, err := CopyTOHLCVs(TOHLCV)
+	if err != nil {
+		return nil, err
+	}
+
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(6))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PatternMarkers{
+		TOHLCVs:      cpy,
+		Matches:      append([]PatternMatch(nil), matches...),
+		ColorBullish: color.RGBA{R: 0, G: 128, B: 0, A: 255},
+		ColorBearish: color.RGBA{R: 196, G: 0, B: 0, A: 255},
+		ColorNeutral: color.RGBA{R: 0, G: 0, B: 196, A: 255},
+		Radius:       vg.Points(2),
+		Gap:          plotter.DefaultLineStyle.Width * 2,
+		TextStyle: draw.TextStyle{
+			Color:  color.Black,
+			Font:   font,
+			XAlign: draw.XCenter,
+		},
+	}, nil
+}
+
+// marker is the position, style and label of a single pattern marker.
+type marker struct {
+	x, y  float64   // data coordinates of the high or low of the bar
+	dy    vg.Length // offset of the marker's center from x, y
+	glyph draw.GlyphStyle
+	label string
+	text  draw.TextStyle
+}
+
+// markers returns the markers of all matches. Markers of the same
+// bar and side are stacked.
+func (pm *PatternMarkers) markers() []marker {
+	type side struct {
+		index int
+		above bool
+	}
+	stacked := make(map[side]int)
+
+	var markers []marker
+	for _, m := range pm.Matches {
+		if m.Index < 0 || m.Index >= len(pm.TOHLCVs) {
+			continue
+		}
+		bar := pm.TOHLCVs[m.Index]
+		if !pm.Viewport.Contains(bar.T) {
+			continue
+		}
+		above := m.Direction != Bullish
+		n := stacked[side{m.Index, above}]
+		stacked[side{m.Index, above}]++
+
+		mk := marker{x: bar.T, text: pm.TextStyle, label: m.Name}
+		mk.glyph.Radius = pm.Radius
+		step := 2*pm.Radius + pm.TextStyle.Height(m.Name)
+		offset := pm.Gap + pm.Radius + vg.Length(n)*step
+		switch m.Direction {
+		case Bullish:
+			mk.y, mk.dy = bar.L, -offset
+			mk.glyph.Color, mk.glyph.Shape = pm.ColorBullish, draw.PyramidGlyph{}
+			mk.text.YAlign = draw.YTop
+		case Bearish:
+			mk.y, mk.dy = bar.H, offset
+			mk.glyph.Color, mk.glyph.Shape = pm.ColorBearish, triangleDownGlyph{}
+			mk.text.YAlign = draw.YBottom
+		default:
+			mk.y, mk.dy = bar.H, offset
+			mk.glyph.Color, mk.glyph.Shape = pm.ColorNeutral, draw.CircleGlyph{}
+			mk.text.YAlign = draw.YBottom
+		}
+		markers = append(markers, mk)
+	}
+	return markers
+}
+
+// labelOffset returns the offset of the label's anchor from the marker's center.
+func (mk marker) labelOffset() vg.Length {
+	if mk.dy < 0 {
+		return -mk.glyph.Radius
+	}
+	return mk.glyph.Radius
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (pm *PatternMarkers) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	for _, mk := range pm.markers() {
+		if !canTransform(plt.Y, mk.y) {
+			continue
+		}
+		pt := vg.Point{X: trX(mk.x), Y: trY(mk.y) + mk.dy}
+		if !c.Contains(pt) {
+			continue
+		}
+		c.DrawGlyph(mk.glyph, pt)
+		c.FillText(mk.text, vg.Point{X: pt.X, Y: pt.Y + mk.labelOffset()}, mk.label)
+	}
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+// There is a glyph box for each marker including its label.
+func (pm *PatternMarkers) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	markers := pm.markers()
+	boxes := make([]plot.GlyphBox, 0, len(markers))
+	for _, mk := range markers {
+		if !canTransform(plt.Y, mk.y) {
+			continue
+		}
+		r := mk.text.Rectangle(mk.label)
+		r.Min.Y += mk.dy + mk.labelOffset()
+		r.Max.Y += mk.dy + mk.labelOffset()
+		r.Min.Y = vg.Length(math.Min(float64(r.Min.Y), float64(mk.dy-mk.glyph.Radius)))
+		r.Max.Y = vg.Length(math.Max(float64(r.Max.Y), float64(mk.dy+mk.glyph.Radius)))
+
+		boxes = append(boxes, plot.GlyphBox{
+			X:         plt.X.Norm(mk.x),
+			Y:         plt.Y.Norm(mk.y),
+			Rectangle: r,
+		})
+	}
+	return boxes
+}
@@ -0,0 +1,80 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestFindPatterns(t *testing.T) {
+	data := custplotter.TOHLCVs{
+		{T: 0, O: 20, H: 20.5, L: 15.5, C: 16},     // long bearish bar
+		{T: 1, O: 15, H: 15.5, L: 14, C: 14.5},     // small bar below the close
+		{T: 2, O: 15, H: 19.5, L: 15, C: 19},       // bullish bar closing above the midpoint: morning star
+		{T: 3, O: 19, H: 20, L: 18, C: 19.02},      // doji
+		{T: 4, O: 19.5, H: 19.6, L: 18.4, C: 18.5}, // bearish bar
+		{T: 5, O: 18.3, H: 20.2, L: 18.2, C: 20},   // bullish bar engulfing the previous one
+	}
+
+	matches, err := custplotter.FindPatterns(data, custplotter.MorningStar, custplotter.Doji, custplotter.BullishEngulfing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []custplotter.PatternMatch{
+		{Index: 2, Name: "Morning Star", Direction: custplotter.Bullish, Bars: 3},
+		{Index: 3, Name: "Doji", Direction: custplotter.Neutral, Bars: 1},
+		{Index: 5, Name: "Bullish Engulfing", Direction: custplotter.Bullish, Bars: 2},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("got matches %v, want %v", matches, want)
+	}
+	for i := range want {
+		if matches[i] != want[i] {
+			t.Errorf("match %d = %+v, want %+v", i, matches[i], want[i])
+		}
+	}
+}
+
+func TestNewPatternMarkers(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	matches, err := custplotter.FindPatterns(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	markers, err := custplotter.NewPatternMarkers(testTOHLCVs, matches)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(sticks, markers)
+
+	testFile := "testdata/patternmarkers.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
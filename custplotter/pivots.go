@@ -0,0 +1,201 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// PivotMethod selects the formulas used to compute pivot levels.
+type PivotMethod int
+
+const (
+	// ClassicPivots are the floor trader pivots P, S1-S3 and R1-R3.
+	ClassicPivots PivotMethod = iota
+	// FibonacciPivots place S1-S3 and R1-R3 at Fibonacci ratios of the range.
+	FibonacciPivots
+	// CamarillaPivots place S1-S4 and R1-R4 around the close.
+	CamarillaPivots
+	// WoodiePivots weight the close twice when computing P.
+	WoodiePivots
+)
+
+// PivotPeriod selects the length of the periods pivot levels are computed for.
+type PivotPeriod int
+
+const (
+	// DailyPivots compute the levels of a session from the previous day.
+	DailyPivots PivotPeriod = iota
+	// WeeklyPivots compute the levels of a week from the previous week.
+	WeeklyPivots
+	// MonthlyPivots compute the levels of a month from the previous month.
+	MonthlyPivots
+)
+
+// PivotLevels are the pivot levels of one period which are
+// computed from the high, low and close of the previous period.
+type PivotLevels struct {
+	// Start and End are the times of the first and the last bar of the period.
+	Start, End float64
+
+	// Names and Values are the names (e. g. "P", "R1", "S1") and the prices
+	// of the levels in ascending order.
+	Names  []string
+	Values []float64
+}
+
+// ComputePivotLevels groups data into periods using the calendar of loc and
+// computes the levels of each period from the previous one. The first period
+// has no previous period and thus no levels. The times of data are seconds
+// since the Unix epoch and must be sorted in ascending order.
+func ComputePivotLevels(data TOHLCVer, method PivotMethod, period PivotPeriod, loc *time.Location) []PivotLevels {
+	if loc == nil {
+		loc = time.UTC
+	}
+	periodKey := func(t float64) int {
+		tm := time.Unix(int64(t), 0).In(loc)
+		switch period {
+		case WeeklyPivots:
+			y, w := tm.ISOWeek()
+			return y*100 + w
+		case MonthlyPivots:
+			return tm.Year()*100 + int(tm.Month())
+		default:
+			return tm.Year()*1000 + tm.YearDay()
+		}
+	}
+
+	var levels []PivotLevels
+	var h, l, c float64 // of the current period
+	key := 0
+	for i := 0; i < data.Len(); i++ {
+		t, _, hi, lo, cl, _ := data.TOHLCV(i)
+		if k := periodKey(t); i == 0 || k != key {
+			if i > 0 {
+				names, values := pivotValues(method, h, l, c)
+				levels = append(levels, PivotLevels{Start: t, End: t, Names: names, Values: values})
+			}
+			key = k
+			h, l = hi, lo
+		} else if len(levels) > 0 {
+			levels[len(levels)-1].End = t
+		}
+		h = math.Max(h, hi)
+		l = math.Min(l, lo)
+		c = cl
+	}
+	return levels
+}
+
+// pivotValues returns the names and values of the pivot levels for the
+// high h, low l and close c of the previous period in ascending order.
+func pivotValues(method PivotMethod, h, l, c float64) ([]string, []float64) {
+	r := h - l
+	switch method {
+	case FibonacciPivots:
+		p := (h + l + c) / 3
+		return []string{"S3", "S2", "S1", "P", "R1", "R2", "R3"},
+			[]float64{p - r, p - 0.618*r, p - 0.382*r, p, p + 0.382*r, p + 0.618*r, p + r}
+	case CamarillaPivots:
+		return []string{"S4", "S3", "S2", "S1", "R1", "R2", "R3", "R4"},
+			[]float64{c - r*1.1/2, c - r*1.1/4, c - r*1.1/6, c - r*1.1/12, c + r*1.1/12, c + r*1.1/6, c + r*1.1/4, c + r*1.1/2}
+	case WoodiePivots:
+		p := (h + l + 2*c) / 4
+		return []string{"S2", "S1", "P", "R1", "R2"},
+			[]float64{p - r, 2*p - h, p, 2*p - l, p + r}
+	default:
+		p := (h + l + c) / 3
+		return []string{"S3", "S2", "S1", "P", "R1", "R2", "R3"},
+			[]float64{l - 2*(h-p), p - r, 2*p - h, p, 2*p - l, p + r, h + 2*(p-l)}
+	}
+}
+
+// Pivots implements the Plotter interface, drawing pivot levels as
+// horizontal segments spanning their period. The levels of the last
+// period are labeled at the right edge of the data canvas.
+type Pivots struct {
+	Levels []PivotLevels
+
+	// LineStyle is the style used to draw the levels.
+	draw.LineStyle
+
+	// TextStyle is the style of the labels.
+	TextStyle draw.TextStyle
+
+	// Format is the format of a label. It gets the name and the value of the level.
+	Format string
+
+	// Viewport, if not nil, restricts the periods which
+	// are drawn to the ones overlapping the viewport.
+	Viewport *Viewport
+}
+
+// NewPivots creates a new pivot levels plotter for the given data.
+func NewPivots(TOHLCV TOHLCVer, method PivotMethod, period PivotPeriod, loc *time.Location) (*Pivots, error) {
+	cpy, err := CopyTOHLCVs(TOHLCV)
+	if err != nil {
+		return nil, err
+	}
+
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(8))
+	if err != nil {
+		return nil, err
+	}
+
+	lineStyle := plotter.DefaultLineStyle
+	lineStyle.Color = color.RGBA{R: 0, G: 0, B: 196, A: 255}
+	lineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+
+	return &Pivots{
+		Levels:    ComputePivotLevels(cpy, method, period, loc),
+		LineStyle: lineStyle,
+		TextStyle: draw.TextStyle{
+			Color:  lineStyle.Color,
+			Font:   font,
+			XAlign: draw.XRight,
+			YAlign: draw.YBottom,
+		},
+		Format: "%s %.2f",
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (pivots *Pivots) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	for i, levels := range pivots.Levels {
+		if vp := pivots.Viewport; vp != nil && (levels.End < vp.From || levels.Start > vp.To) {
+			continue
+		}
+		last := i == len(pivots.Levels)-1
+		xmin := trX(levels.Start)
+		xmax := trX(levels.End)
+		if last {
+			xmax = c.Max.X
+		}
+
+		for j, value := range levels.Values {
+			// levels below zero cannot be shown on a log scale
+			if !canTransform(plt.Y, value) {
+				continue
+			}
+			y := trY(value)
+			lines := c.ClipLinesXY([]vg.Point{{X: xmin, Y: y}, {X: xmax, Y: y}})
+			c.StrokeLines(pivots.LineStyle, lines...)
+
+			if last && c.ContainsY(y) {
+				c.FillText(pivots.TextStyle, vg.Point{X: c.Max.X, Y: y}, fmt.Sprintf(pivots.Format, levels.Names[j], value))
+			}
+		}
+	}
+}
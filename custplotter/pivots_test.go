@@ -0,0 +1,97 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestComputePivotLevels(t *testing.T) {
+	day := float64(24 * 60 * 60)
+	data := custplotter.TOHLCVs{
+		{T: 0, O: 10, H: 12, L: 9, C: 11, V: 1},
+		{T: day / 2, O: 11, H: 13, L: 10, C: 12, V: 1},
+		{T: day, O: 12, H: 12.5, L: 11, C: 11.5, V: 1},
+		{T: day * 3 / 2, O: 11.5, H: 14, L: 11, C: 13, V: 1},
+		{T: 2 * day, O: 13, H: 13, L: 12, C: 12.5, V: 1},
+	}
+
+	levels := custplotter.ComputePivotLevels(data, custplotter.ClassicPivots, custplotter.DailyPivots, time.UTC)
+	if len(levels) != 2 {
+		t.Fatalf("got %d periods, want 2", len(levels))
+	}
+
+	// the second day is computed from H = 13, L = 9, C = 12 of the first day
+	p := (13.0 + 9.0 + 12.0) / 3
+	want := []float64{9 - 2*(13-p), p - 4, 2*p - 13, p, 2*p - 9, p + 4, 13 + 2*(p-9)}
+	if levels[0].Start != day || levels[0].End != day*3/2 {
+		t.Errorf("period = [%v, %v], want [%v, %v]", levels[0].Start, levels[0].End, day, day*3/2)
+	}
+	for i, v := range levels[0].Values {
+		if math.Abs(v-want[i]) > 1e-9 {
+			t.Errorf("level %s = %v, want %v", levels[0].Names[i], v, want[i])
+		}
+	}
+
+	// the third day is computed from H = 14, L = 11, C = 13 of the second day
+	if levels[1].Start != 2*day || levels[1].End != 2*day {
+		t.Errorf("period = [%v, %v], want [%v, %v]", levels[1].Start, levels[1].End, 2*day, 2*day)
+	}
+	if p := (14.0 + 11.0 + 13.0) / 3; math.Abs(levels[1].Values[3]-p) > 1e-9 {
+		t.Errorf("P = %v, want %v", levels[1].Values[3], p)
+	}
+
+	for _, method := range []custplotter.PivotMethod{custplotter.FibonacciPivots, custplotter.CamarillaPivots, custplotter.WoodiePivots} {
+		for _, l := range custplotter.ComputePivotLevels(data, method, custplotter.DailyPivots, time.UTC) {
+			for i := 1; i < len(l.Values); i++ {
+				if l.Values[i] < l.Values[i-1] {
+					t.Errorf("method %d: levels not in ascending order: %v", method, l.Values)
+				}
+			}
+		}
+	}
+}
+
+func TestNewPivots(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+	// spread the bars over several days
+	for i := range testTOHLCVs {
+		testTOHLCVs[i].T = testTOHLCVs[0].T + float64(i*6*60*60)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	pivots, err := custplotter.NewPivots(testTOHLCVs, custplotter.ClassicPivots, custplotter.DailyPivots, time.UTC)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(sticks, pivots)
+
+	testFile := "testdata/pivots.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
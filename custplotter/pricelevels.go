@@ -0,0 +1,198 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"fmt"
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Level is a horizontal price level, e. g. a stop-loss, a take-profit
+// or an alert level.
+type Level struct {
+	Price float64
+
+	// Label is written in front of the price in the price tag.
+	Label string
+
+	// Color is the color of the line and the price tag. The color
+	// of the LineStyle of the plotter is used if Color is nil.
+	Color color.Color
+}
+
+// PriceLevels implements the Plotter interface, drawing horizontal lines
+// spanning the full width of the data canvas. Each line has a filled price
+// tag at the right edge of the data canvas.
+type PriceLevels struct {
+	Levels []Level
+
+	// TOHLCVs are the bars used for the last price level.
+	TOHLCVs
+
+	// LastPrice determines if a level is drawn at the close of the last bar.
+	LastPrice bool
+
+	// ColorUp is the color of the last price level if C >= O for the last bar.
+	ColorUp color.Color
+
+	// ColorDown is the color of the last price level if C < O for the last bar.
+	ColorDown color.Color
+
+	// LineStyle is the style used to draw the lines.
+	draw.LineStyle
+
+	// TextStyle is the style of the text in the price tags.
+	TextStyle draw.TextStyle
+
+	// Format is the format of the price in a price tag.
+	Format string
+
+	// Padding is the padding between the text and the border of a price tag.
+	Padding vg.Length
+}
+
+// NewPriceLevels creates a new price level plotter for the given levels.
+func NewPriceLevels(levels ...Level) (*PriceLevels, error) {
+	for _, l := range levels {
+		if err := plotter.CheckFloats(l.Price); err != nil {
+			return nil, err
+		}
+	}
+
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(8))
+	if err != nil {
+		return nil, err
+	}
+
+	lineStyle := plotter.DefaultLineStyle
+	lineStyle.Color = color.RGBA{R: 0, G: 0, B: 196, A: 255}
+	lineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+
+	return &PriceLevels{
+		Levels:    append([]Level(nil), levels...),
+		ColorUp:   color.RGBA{R: 0, G: 128, B: 0, A: 255}, // eye is more sensible to green
+		ColorDown: color.RGBA{R: 196, G: 0, B: 0, A: 255},
+		LineStyle: lineStyle,
+		TextStyle: draw.TextStyle{
+			Color:  color.White,
+			Font:   font,
+			YAlign: draw.YCenter,
+		},
+		Format:  "%.2f",
+		Padding: vg.Points(1.5),
+	}, nil
+}
+
+// NewLastPriceLevel creates a new price level plotter drawing
+// a level at the close of the last bar of the given data.
+func NewLastPriceLevel(TOHLCV TOHLCVer) (*PriceLevels, error) {
+	cpy, err := CopyTOHLCVs(TOHLCV)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := NewPriceLevels()
+	if err != nil {
+		return nil, err
+	}
+	levels.TOHLCVs = cpy
+	levels.LastPrice = true
+
+	return levels, nil
+}
+
+// allLevels returns the levels including the last price level.
+func (pl *PriceLevels) allLevels() []Level {
+	levels := pl.Levels
+	if pl.LastPrice && len(pl.TOHLCVs) > 0 {
+		last := pl.TOHLCVs[len(pl.TOHLCVs)-1]
+		clr := pl.ColorUp
+		if last.C < last.O {
+			clr = pl.ColorDown
+		}
+		levels = append(levels[:len(levels):len(levels)], Level{Price: last.C, Color: clr})
+	}
+	return levels
+}
+
+// tag returns the text of the price tag of l.
+func (pl *PriceLevels) tag(l Level) string {
+	txt := fmt.Sprintf(pl.Format, l.Price)
+	if l.Label != "" {
+		txt = l.Label + " " + txt
+	}
+	return txt
+}
+
+// tagSize returns the width of the notch and the size of the price tag with text txt.
+func (pl *PriceLevels) tagSize(txt string) (notch, width, height vg.Length) {
+	height = pl.TextStyle.Height(txt) + 2*pl.Padding
+	return height / 2, pl.TextStyle.Width(txt) + 2*pl.Padding, height
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (pl *PriceLevels) Plot(c draw.Canvas, plt *plot.Plot) {
+	_, trY := plt.Transforms(&c)
+	lineStyle := pl.LineStyle
+
+	for _, l := range pl.allLevels() {
+		if !canTransform(plt.Y, l.Price) {
+			continue
+		}
+		y := trY(l.Price)
+		if !c.ContainsY(y) {
+			continue
+		}
+
+		clr := l.Color
+		if clr == nil {
+			clr = pl.LineStyle.Color
+		}
+		lineStyle.Color = clr
+		c.StrokeLine2(lineStyle, c.Min.X, y, c.Max.X, y)
+
+		// The tag is drawn to the right of the data canvas
+		// into the space reserved by GlyphBoxes.
+		txt := pl.tag(l)
+		notch, w, h := pl.tagSize(txt)
+		x := c.Max.X
+		c.FillPolygon(clr, []vg.Point{
+			{X: x, Y: y},
+			{X: x + notch, Y: y + h/2},
+			{X: x + notch + w, Y: y + h/2},
+			{X: x + notch + w, Y: y - h/2},
+			{X: x + notch, Y: y - h/2},
+		})
+		c.FillText(pl.TextStyle, vg.Point{X: x + notch + pl.Padding, Y: y}, txt)
+	}
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+// The glyph boxes reserve the space for the price tags
+// to the right of the data canvas.
+func (pl *PriceLevels) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	var boxes []plot.GlyphBox
+	for _, l := range pl.allLevels() {
+		if !canTransform(plt.Y, l.Price) {
+			continue
+		}
+		notch, w, h := pl.tagSize(pl.tag(l))
+		boxes = append(boxes, plot.GlyphBox{
+			X: 1,
+			Y: plt.Y.Norm(l.Price),
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: 0, Y: -h / 2},
+				Max: vg.Point{X: notch + w, Y: h / 2},
+			},
+		})
+	}
+	return boxes
+}
@@ -0,0 +1,50 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"image/color"
+	"log"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestNewPriceLevels(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	levels, err := custplotter.NewLastPriceLevel(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+	levels.Levels = []custplotter.Level{
+		{Price: 102.5, Label: "SL", Color: color.RGBA{R: 196, A: 255}},
+		{Price: 105.5, Label: "TP"},
+	}
+
+	p.Add(sticks, levels)
+
+	testFile := "testdata/pricelevels.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
@@ -0,0 +1,104 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+)
+
+// isLogScale returns whether the normalizer n
+// only accepts positive values.
+func isLogScale(n plot.Normalizer) bool {
+	switch n := n.(type) {
+	case plot.LogScale:
+		return true
+	case plot.InvertedScale:
+		return isLogScale(n.Normalizer)
+	case PercentScale:
+		return n.Log
+	}
+	return false
+}
+
+// canTransform returns whether v can be transformed by the axis a,
+// i.e. v is positive if a has a log scale.
+func canTransform(a plot.Axis, v float64) bool {
+	return v > 0 || !isLogScale(a.Scale)
+}
+
+// PercentScale is suitable for the Scale and the Tick.Marker fields of an
+// Axis showing prices. Set both fields to the same PercentScale to label
+// the axis in percent change relative to the reference price Ref.
+//
+// The prices are scaled linearly or, if Log is set, logarithmically
+// so that equal percent changes have equal distances. Only positive
+// prices can be shown if Log is set.
+type PercentScale struct {
+	// Ref is the reference price, e.g. the close
+	// of the first visible bar (see ReferenceClose).
+	Ref float64
+
+	// Log determines if the prices are scaled logarithmically.
+	Log bool
+}
+
+var (
+	_ plot.Normalizer = PercentScale{}
+	_ plot.Ticker     = PercentScale{}
+)
+
+// Normalize implements the Normalize method of the plot.Normalizer interface.
+func (ps PercentScale) Normalize(min, max, x float64) float64 {
+	if ps.Log {
+		return plot.LogScale{}.Normalize(min, max, x)
+	}
+	return plot.LinearScale{}.Normalize(min, max, x)
+}
+
+// Ticks implements the Ticks method of the plot.Ticker interface.
+// The ticks are placed at round percent changes relative to Ref.
+// If Ref is 0, NaN or infinite, e.g. if ReferenceClose found no bar,
+// the ticks of plot.DefaultTicks are returned.
+func (ps PercentScale) Ticks(min, max float64) []plot.Tick {
+	if ps.Ref == 0 || math.IsNaN(ps.Ref) || math.IsInf(ps.Ref, 0) {
+		return plot.DefaultTicks{}.Ticks(min, max)
+	}
+
+	pmin := (min/ps.Ref - 1) * 100
+	pmax := (max/ps.Ref - 1) * 100
+	if pmax < pmin {
+		pmin, pmax = pmax, pmin
+	}
+
+	ticks := plot.DefaultTicks{}.Ticks(pmin, pmax)
+	for i, t := range ticks {
+		ticks[i].Value = ps.Ref * (1 + t.Value/100)
+		if t.Label == "" {
+			continue
+		}
+		if t.Value > 0 {
+			ticks[i].Label = "+" + t.Label
+		}
+		ticks[i].Label += "%"
+	}
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i].Value < ticks[j].Value })
+	return ticks
+}
+
+// ReferenceClose returns the close of the first bar of data at or after
+// time t, e.g. of the first visible bar if t is the minimum of the X axis.
+// It returns NaN if there is no such bar.
+func ReferenceClose(data TOHLCVer, t float64) float64 {
+	for i := 0; i < data.Len(); i++ {
+		ti, _, _, _, c, _ := data.TOHLCV(i)
+		if ti >= t {
+			return c
+		}
+	}
+	return math.NaN()
+}
@@ -0,0 +1,208 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestPercentScaleTicks(t *testing.T) {
+	ps := custplotter.PercentScale{Ref: 200}
+
+	want := map[string]float64{"-5%": 190, "0%": 200, "+5%": 210, "+10%": 220}
+	for _, tick := range ps.Ticks(188, 222) {
+		if tick.Label == "" {
+			continue
+		}
+		v, ok := want[tick.Label]
+		if !ok {
+			t.Errorf("unexpected tick %q at %v", tick.Label, tick.Value)
+			continue
+		}
+		if math.Abs(tick.Value-v) > 1e-9 {
+			t.Errorf("tick %q at %v, want %v", tick.Label, tick.Value, v)
+		}
+		delete(want, tick.Label)
+	}
+	for label := range want {
+		t.Errorf("missing tick %q", label)
+	}
+}
+
+func TestPercentScaleTicksInvalidRef(t *testing.T) {
+	want := plot.DefaultTicks{}.Ticks(100, 110)
+	for _, ref := range []float64{0, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		got := custplotter.PercentScale{Ref: ref}.Ticks(100, 110)
+		if len(got) != len(want) {
+			t.Errorf("Ref %v: got %d ticks, want the %d default ticks", ref, len(got), len(want))
+			continue
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("Ref %v: tick %d is %v, want %v", ref, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestReferenceClose(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	if got := custplotter.ReferenceClose(testTOHLCVs, testTOHLCVs[3].T-1); got != testTOHLCVs[3].C {
+		t.Errorf("ReferenceClose() = %v, want %v", got, testTOHLCVs[3].C)
+	}
+	if got := custplotter.ReferenceClose(testTOHLCVs, testTOHLCVs[19].T+1); !math.IsNaN(got) {
+		t.Errorf("ReferenceClose() = %v, want NaN", got)
+	}
+}
+
+func TestPercentLogScale(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+	scale := custplotter.PercentScale{Ref: custplotter.ReferenceClose(testTOHLCVs, testTOHLCVs[0].T), Log: true}
+	p.Y.Scale = scale
+	p.Y.Tick.Marker = scale
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// the level at zero is skipped on a log scale
+	levels, err := custplotter.NewPriceLevels(custplotter.Level{Price: 0}, custplotter.Level{Price: 104})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(sticks, levels)
+
+	testFile := "testdata/percentlogscale.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
+
+func TestVBarsLogScale(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+	testTOHLCVs[5].V = 0
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+	p.Y.Scale = plot.LogScale{}
+	p.Y.Tick.Marker = plot.LogTicks{}
+
+	bars, err := custplotter.NewVBars(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+	bars.PositiveOnly = true
+
+	p.Add(bars)
+
+	testFile := "testdata/vbarslogscale.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
+
+func TestOverlaysLogScale(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+	// spread the bars over several days and shift the prices,
+	// so that some lows and pivot levels are below zero
+	for i := range testTOHLCVs {
+		testTOHLCVs[i].T = testTOHLCVs[0].T + float64(i*6*60*60)
+		testTOHLCVs[i].O -= 101
+		testTOHLCVs[i].H -= 101
+		testTOHLCVs[i].L -= 101
+		testTOHLCVs[i].C -= 101
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+	p.Y.Scale = plot.LogScale{}
+	p.Y.Tick.Marker = plot.LogTicks{}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+	sticks.PositiveOnly = true
+
+	pivots, err := custplotter.NewPivots(testTOHLCVs, custplotter.ClassicPivots, custplotter.DailyPivots, time.UTC)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	var swings []custplotter.SwingPoint
+	for _, i := range []int{0, 9, 19} {
+		swings = append(swings, custplotter.SwingPoint{T: testTOHLCVs[i].T, Price: testTOHLCVs[i].L})
+	}
+	zigzag, err := custplotter.NewZigZag(swings)
+	if err != nil {
+		log.Panic(err)
+	}
+	zigzag.PositiveOnly = true
+
+	markers, err := custplotter.NewTradeMarkers(testTOHLCVs, []custplotter.Fill{
+		{T: testTOHLCVs[0].T, Price: testTOHLCVs[0].L, Quantity: 1, Side: custplotter.Sell},
+		{T: testTOHLCVs[1].T, Price: testTOHLCVs[1].L, Quantity: 2, Side: custplotter.Buy},
+		{T: testTOHLCVs[12].T, Price: testTOHLCVs[12].C, Quantity: 1, Side: custplotter.Sell},
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+	markers.PositiveOnly = true
+
+	measurement, err := custplotter.NewMeasurementBars(testTOHLCVs, 0, 19)
+	if err != nil {
+		log.Panic(err)
+	}
+	measurement.PositiveOnly = true
+
+	fib, err := custplotter.NewFibRetracementAuto(testTOHLCVs, 0, 19)
+	if err != nil {
+		log.Panic(err)
+	}
+	fib.Levels = []float64{0, 0.5, 1}
+	fib.InDataRange = true
+	fib.PositiveOnly = true
+
+	p.Add(sticks, pivots, zigzag, markers, measurement, fib)
+
+	testFile := "testdata/overlayslogscale.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
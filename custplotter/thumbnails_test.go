@@ -0,0 +1,60 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+var (
+	_ plot.Thumbnailer = (*custplotter.Candlesticks)(nil)
+	_ plot.Thumbnailer = (*custplotter.OHLCBars)(nil)
+	_ plot.Thumbnailer = (*custplotter.VBars)(nil)
+)
+
+func TestThumbnails(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+	sticks.FixedLineColor = false
+
+	bars, err := custplotter.NewOHLCBars(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	vbars, err := custplotter.NewVBars(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(sticks)
+	p.Legend.Add("candles", sticks)
+	p.Legend.Add("bars", bars)
+	p.Legend.Add("volume", vbars)
+	p.Legend.Top = true
+
+	testFile := "testdata/thumbnails.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
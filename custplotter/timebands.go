@@ -0,0 +1,114 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// TimeBand is the time range from Start to End given in seconds since the Unix epoch.
+type TimeBand struct {
+	Start, End float64
+}
+
+// SessionBands returns a band for each daily session from the time of day
+// start to the time of day end in loc which overlaps the range from tmin to
+// tmax. Sessions with end before start span midnight, so swapping start and
+// end of the regular session returns the pre-market and after-hours bands.
+func SessionBands(tmin, tmax float64, loc *time.Location, start, end time.Duration) []TimeBand {
+	if loc == nil {
+		loc = time.UTC
+	}
+	atTimeOfDay := func(day time.Time, d time.Duration) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, int(d), loc)
+	}
+
+	first := time.Unix(int64(math.Floor(tmin)), 0).In(loc).AddDate(0, 0, -1)
+	var bands []TimeBand
+	for day := first; float64(atTimeOfDay(day, start).Unix()) <= tmax; day = day.AddDate(0, 0, 1) {
+		s := atTimeOfDay(day, start)
+		e := atTimeOfDay(day, end)
+		if end <= start {
+			e = atTimeOfDay(day.AddDate(0, 0, 1), end)
+		}
+		if float64(e.Unix()) < tmin {
+			continue
+		}
+		bands = append(bands, TimeBand{Start: float64(s.Unix()), End: float64(e.Unix())})
+	}
+	return bands
+}
+
+// WeekendBands returns a band from Saturday 00:00 to Monday 00:00 in loc
+// for each weekend overlapping the range from tmin to tmax.
+func WeekendBands(tmin, tmax float64, loc *time.Location) []TimeBand {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	t := time.Unix(int64(math.Floor(tmin)), 0).In(loc)
+	// go back to the Saturday of the current or the previous weekend
+	t = time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+1)%7, 0, 0, 0, 0, loc)
+
+	var bands []TimeBand
+	for ; float64(t.Unix()) <= tmax; t = t.AddDate(0, 0, 7) {
+		e := t.AddDate(0, 0, 2)
+		if float64(e.Unix()) < tmin {
+			continue
+		}
+		bands = append(bands, TimeBand{Start: float64(t.Unix()), End: float64(e.Unix())})
+	}
+	return bands
+}
+
+// TimeBands implements the Plotter interface, shading vertical bands
+// over the full height of the data canvas.
+//
+// TimeBands should be added to a plot before Candlesticks, OHLCBars or
+// VBars so that it is drawn beneath the bars. The bands only depend on
+// the X axis, thus they line up across all plots aligned by Table.Align
+// if the X axes have the same range (see UniteAxisRanges).
+type TimeBands struct {
+	Bands []TimeBand
+
+	// Color is the fill color of the bands.
+	Color color.Color
+}
+
+// NewTimeBands creates a new time band plotter for the given bands.
+func NewTimeBands(bands ...TimeBand) (*TimeBands, error) {
+	for _, b := range bands {
+		if err := plotter.CheckFloats(b.Start, b.End); err != nil {
+			return nil, err
+		}
+	}
+
+	return &TimeBands{
+		Bands: append([]TimeBand(nil), bands...),
+		Color: color.Gray{Y: 232},
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (tb *TimeBands) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, _ := plt.Transforms(&c)
+
+	for _, b := range tb.Bands {
+		xmin := trX(math.Min(b.Start, b.End))
+		xmax := trX(math.Max(b.Start, b.End))
+		if xmax < c.Min.X || xmin > c.Max.X {
+			continue
+		}
+		band := c.ClipPolygonX([]vg.Point{{X: xmin, Y: c.Min.Y}, {X: xmax, Y: c.Min.Y}, {X: xmax, Y: c.Max.Y}, {X: xmin, Y: c.Max.Y}})
+		c.FillPolygon(tb.Color, band)
+	}
+}
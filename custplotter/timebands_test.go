@@ -0,0 +1,93 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"testing"
+	"time"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestSessionBands(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// the daylight saving time starts on Sunday, 2018-03-11
+	tmin := float64(time.Date(2018, 3, 9, 12, 0, 0, 0, loc).Unix())
+	tmax := float64(time.Date(2018, 3, 12, 12, 0, 0, 0, loc).Unix())
+
+	bands := custplotter.SessionBands(tmin, tmax, loc, 9*time.Hour+30*time.Minute, 16*time.Hour)
+	if len(bands) != 4 {
+		t.Fatalf("got %d bands, want 4", len(bands))
+	}
+	for i, b := range bands {
+		s := time.Unix(int64(b.Start), 0).In(loc)
+		e := time.Unix(int64(b.End), 0).In(loc)
+		if s.Day() != 9+i || s.Hour() != 9 || s.Minute() != 30 || e.Day() != 9+i || e.Hour() != 16 || e.Minute() != 0 {
+			t.Errorf("band %d = %v - %v", i, s, e)
+		}
+	}
+
+	// swapped start and end return the bands between the sessions
+	bands = custplotter.SessionBands(tmin, tmax, loc, 16*time.Hour, 9*time.Hour+30*time.Minute)
+	if len(bands) != 3 {
+		t.Fatalf("got %d bands, want 3", len(bands))
+	}
+	s := time.Unix(int64(bands[0].Start), 0).In(loc)
+	e := time.Unix(int64(bands[0].End), 0).In(loc)
+	if s.Day() != 9 || s.Hour() != 16 || e.Day() != 10 || e.Hour() != 9 || e.Minute() != 30 {
+		t.Errorf("first band = %v - %v", s, e)
+	}
+
+	bands = custplotter.WeekendBands(tmin, tmax, loc)
+	if len(bands) != 1 {
+		t.Fatalf("got %d weekend bands, want 1", len(bands))
+	}
+	s = time.Unix(int64(bands[0].Start), 0).In(loc)
+	e = time.Unix(int64(bands[0].End), 0).In(loc)
+	if s.Weekday() != time.Saturday || s.Hour() != 0 || e.Weekday() != time.Monday || e.Hour() != 0 {
+		t.Errorf("weekend band = %v - %v", s, e)
+	}
+}
+
+func TestNewTimeBands(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	bands, err := custplotter.NewTimeBands(
+		custplotter.TimeBand{Start: testTOHLCVs[3].T, End: testTOHLCVs[7].T},
+		custplotter.TimeBand{Start: testTOHLCVs[15].T, End: testTOHLCVs[19].T + 600},
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(bands, sticks)
+
+	testFile := "testdata/timebands.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
@@ -0,0 +1,295 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Side is the side of a fill.
+type Side int
+
+const (
+	// Buy fills increase the position.
+	Buy Side = iota
+	// Sell fills decrease the position.
+	Sell
+)
+
+// Fill is an executed order. The quantity must not be negative,
+// the direction is given by the side.
+type Fill struct {
+	T, Price, Quantity float64
+	Side               Side
+}
+
+// roundTrip is a trade from a flat position back to a flat position.
+type roundTrip struct {
+	entryT, entryPrice float64
+	exitT, exitPrice   float64
+	pnl                float64
+}
+
+// roundTrips pairs the fills, which must be sorted by time, to round trips.
+// A fill that reverses the position closes the current round trip and opens
+// a new one with the remaining quantity.
+func roundTrips(fills []Fill) []roundTrip {
+	var trips []roundTrip
+	var pos, cost, pnl float64 // signed position, its signed cost and the realized P&L
+	var entryT, entryPrice float64
+	for _, f := range fills {
+		q := f.Quantity
+		if f.Side == Sell {
+			q = -q
+		}
+		switch {
+		case pos == 0:
+			entryT, entryPrice = f.T, f.Price
+			pos, cost, pnl = q, q*f.Price, 0
+		case pos*q > 0:
+			pos += q
+			cost += q * f.Price
+		case math.Abs(q) < math.Abs(pos):
+			avg := cost / pos
+			pnl += -q * (f.Price - avg)
+			pos += q
+			cost = pos * avg
+		default:
+			avg := cost / pos
+			trips = append(trips, roundTrip{
+				entryT: entryT, entryPrice: entryPrice,
+				exitT: f.T, exitPrice: f.Price,
+				pnl: pnl + pos*(f.Price-avg),
+			})
+			pos, cost, pnl = pos+q, (pos+q)*f.Price, 0
+			entryT, entryPrice = f.T, f.Price
+		}
+	}
+	return trips
+}
+
+// TradeMarkers implements the Plotter interface, drawing a triangle for
+// each fill at the bar nearest to the time of the fill. Buy triangles
+// point up and sell triangles point down. The size of a triangle grows
+// with the quantity of the fill.
+type TradeMarkers struct {
+	// TOHLCVs are the bars the markers are snapped to.
+	TOHLCVs
+
+	// Fills are the fills sorted by time.
+	Fills []Fill
+
+	// ColorBuy and ColorSell are the colors of buy and sell markers.
+	ColorBuy, ColorSell color.Color
+
+	// MinRadius and MaxRadius are the radii of the markers of the
+	// smallest and the largest fill.
+	MinRadius, MaxRadius vg.Length
+
+	// Connectors determines if lines connecting the entry and the exit
+	// of each round trip are drawn.
+	Connectors bool
+
+	// ColorProfit and ColorLoss are the colors of the connectors
+	// of winning and losing round trips.
+	ColorProfit, ColorLoss color.Color
+
+	// LineStyle is the style used to draw the connectors.
+	draw.LineStyle
+
+	// PositiveOnly determines if DataRange ignores fills with
+	// non-positive prices, which cannot be shown on a log scale
+	// (plot.LogScale). Such fills are never drawn on a log scale.
+	PositiveOnly bool
+
+	// Viewport, if not nil, restricts the markers which are drawn and
+	// covered by DataRange to the fills at bars within the viewport.
+	// Connectors are only drawn if the entry and the exit are within.
+	Viewport *Viewport
+}
+
+// NewTradeMarkers creates a new trade marker plotter for
+// the given bars and fills.
+func NewTradeMarkers(TOHLCV TOHLCVer, fills []Fill) (*TradeMarkers, error) {
+	cpy, err := CopyTOHLCVs(TOHLCV)
+	if err != nil {
+		return nil, err
+	}
+
+	fillsCpy := make([]Fill, len(fills))
+	for i, f := range fills {
+		if err := plotter.CheckFloats(f.T, f.Price, f.Quantity); err != nil {
+			return nil, err
+		}
+		if f.Quantity < 0 {
+			return nil, fmt.Errorf("custplotter: negative quantity %v of fill at %v", f.Quantity, f.T)
+		}
+		fillsCpy[i] = f
+	}
+	sort.SliceStable(fillsCpy, func(i, j int) bool { return fillsCpy[i].T < fillsCpy[j].T })
+
+	lineStyle := plotter.DefaultLineStyle
+	lineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+
+	return &TradeMarkers{
+		TOHLCVs:     cpy,
+		Fills:       fillsCpy,
+		ColorBuy:    color.RGBA{R: 0, G: 128, B: 0, A: 255},
+		ColorSell:   color.RGBA{R: 196, G: 0, B: 0, A: 255},
+		MinRadius:   vg.Points(2),
+		MaxRadius:   vg.Points(5),
+		ColorProfit: color.RGBA{R: 0, G: 128, B: 0, A: 255},
+		ColorLoss:   color.RGBA{R: 196, G: 0, B: 0, A: 255},
+		LineStyle:   lineStyle,
+	}, nil
+}
+
+// snap returns the time of the bar nearest to t.
+// The bars are assumed to be sorted by time.
+func (tm *TradeMarkers) snap(t float64) float64 {
+	n := len(tm.TOHLCVs)
+	if n == 0 {
+		return t
+	}
+	i := sort.Search(n, func(i int) bool { return tm.TOHLCVs[i].T >= t })
+	switch {
+	case i == 0:
+		return tm.TOHLCVs[0].T
+	case i == n:
+		return tm.TOHLCVs[n-1].T
+	case t-tm.TOHLCVs[i-1].T <= tm.TOHLCVs[i].T-t:
+		return tm.TOHLCVs[i-1].T
+	default:
+		return tm.TOHLCVs[i].T
+	}
+}
+
+// maxQuantity returns the largest quantity of the fills.
+func (tm *TradeMarkers) maxQuantity() float64 {
+	var maxQ float64
+	for _, f := range tm.Fills {
+		maxQ = math.Max(maxQ, f.Quantity)
+	}
+	return maxQ
+}
+
+// radius returns the radius of the marker of a fill with quantity q
+// where maxQ is the largest quantity (see maxQuantity).
+// The radius grows with the square root of the quantity from MinRadius
+// for a zero quantity to MaxRadius for maxQ.
+func (tm *TradeMarkers) radius(q, maxQ float64) vg.Length {
+	if maxQ == 0 {
+		return tm.MinRadius
+	}
+	return tm.MinRadius + (tm.MaxRadius-tm.MinRadius)*vg.Length(math.Sqrt(q/maxQ))
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (tm *TradeMarkers) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	if tm.Connectors {
+		lineStyle := tm.LineStyle
+		for _, trip := range roundTrips(tm.Fills) {
+			if !canTransform(plt.Y, trip.entryPrice) || !canTransform(plt.Y, trip.exitPrice) {
+				continue
+			}
+			if !tm.Viewport.Contains(tm.snap(trip.entryT)) || !tm.Viewport.Contains(tm.snap(trip.exitT)) {
+				continue
+			}
+			lineStyle.Color = tm.ColorProfit
+			if trip.pnl < 0 {
+				lineStyle.Color = tm.ColorLoss
+			}
+			line := c.ClipLinesXY([]vg.Point{
+				{X: trX(tm.snap(trip.entryT)), Y: trY(trip.entryPrice)},
+				{X: trX(tm.snap(trip.exitT)), Y: trY(trip.exitPrice)},
+			})
+			c.StrokeLines(lineStyle, line...)
+		}
+	}
+
+	maxQ := tm.maxQuantity()
+	for _, f := range tm.Fills {
+		if !canTransform(plt.Y, f.Price) || !tm.Viewport.Contains(tm.snap(f.T)) {
+			continue
+		}
+		pt := vg.Point{X: trX(tm.snap(f.T)), Y: trY(f.Price)}
+		if !c.Contains(pt) {
+			continue
+		}
+		sty := draw.GlyphStyle{Color: tm.ColorBuy, Radius: tm.radius(f.Quantity, maxQ), Shape: draw.PyramidGlyph{}}
+		if f.Side == Sell {
+			sty.Color, sty.Shape = tm.ColorSell, triangleDownGlyph{}
+		}
+		c.DrawGlyph(sty, pt)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (tm *TradeMarkers) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin = math.Inf(1)
+	xmax = math.Inf(-1)
+	ymin = math.Inf(1)
+	ymax = math.Inf(-1)
+	for _, f := range tm.Fills {
+		t := tm.snap(f.T)
+		if !tm.Viewport.Contains(t) || tm.PositiveOnly && f.Price <= 0 {
+			continue
+		}
+		xmin = math.Min(xmin, t)
+		xmax = math.Max(xmax, t)
+		ymin = math.Min(ymin, f.Price)
+		ymax = math.Max(ymax, f.Price)
+	}
+	return
+}
+
+// WindowDataRange returns the Y range of the fills
+// snapped to bars with T in [xmin, xmax] like DataRange.
+func (tm *TradeMarkers) WindowDataRange(xmin, xmax float64) (ymin, ymax float64) {
+	ymin = math.Inf(1)
+	ymax = math.Inf(-1)
+	for _, f := range tm.Fills {
+		if t := tm.snap(f.T); t < xmin || t > xmax || !tm.Viewport.Contains(t) || tm.PositiveOnly && f.Price <= 0 {
+			continue
+		}
+		ymin = math.Min(ymin, f.Price)
+		ymax = math.Max(ymax, f.Price)
+	}
+	return
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+// There is a glyph box for each marker.
+func (tm *TradeMarkers) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	boxes := make([]plot.GlyphBox, 0, len(tm.Fills))
+	maxQ := tm.maxQuantity()
+	for _, f := range tm.Fills {
+		if !canTransform(plt.Y, f.Price) || !tm.Viewport.Contains(tm.snap(f.T)) {
+			continue
+		}
+		r := tm.radius(f.Quantity, maxQ)
+		boxes = append(boxes, plot.GlyphBox{
+			X: plt.X.Norm(tm.snap(f.T)),
+			Y: plt.Y.Norm(f.Price),
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: -r, Y: -r},
+				Max: vg.Point{X: +r, Y: +r},
+			},
+		})
+	}
+	return boxes
+}
@@ -0,0 +1,82 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import "testing"
+
+func TestRoundTrips(t *testing.T) {
+	tests := []struct {
+		name  string
+		fills []Fill
+		want  []roundTrip
+	}{
+		{
+			name: "long win",
+			fills: []Fill{
+				{T: 1, Price: 10, Quantity: 1, Side: Buy},
+				{T: 2, Price: 12, Quantity: 1, Side: Sell},
+			},
+			want: []roundTrip{{entryT: 1, entryPrice: 10, exitT: 2, exitPrice: 12, pnl: 2}},
+		},
+		{
+			name: "short loss",
+			fills: []Fill{
+				{T: 1, Price: 10, Quantity: 2, Side: Sell},
+				{T: 2, Price: 11, Quantity: 2, Side: Buy},
+			},
+			want: []roundTrip{{entryT: 1, entryPrice: 10, exitT: 2, exitPrice: 11, pnl: -2}},
+		},
+		{
+			name: "scaling in",
+			fills: []Fill{
+				{T: 1, Price: 10, Quantity: 1, Side: Buy},
+				{T: 2, Price: 12, Quantity: 1, Side: Buy},
+				{T: 3, Price: 13, Quantity: 2, Side: Sell},
+			},
+			want: []roundTrip{{entryT: 1, entryPrice: 10, exitT: 3, exitPrice: 13, pnl: 4}},
+		},
+		{
+			name: "partial exits",
+			fills: []Fill{
+				{T: 1, Price: 10, Quantity: 2, Side: Buy},
+				{T: 2, Price: 12, Quantity: 1, Side: Sell},
+				{T: 3, Price: 9, Quantity: 1, Side: Sell},
+			},
+			want: []roundTrip{{entryT: 1, entryPrice: 10, exitT: 3, exitPrice: 9, pnl: 1}},
+		},
+		{
+			name: "reversal",
+			fills: []Fill{
+				{T: 1, Price: 10, Quantity: 1, Side: Buy},
+				{T: 2, Price: 12, Quantity: 3, Side: Sell},
+				{T: 3, Price: 11, Quantity: 2, Side: Buy},
+			},
+			want: []roundTrip{
+				{entryT: 1, entryPrice: 10, exitT: 2, exitPrice: 12, pnl: 2},
+				{entryT: 2, entryPrice: 12, exitT: 3, exitPrice: 11, pnl: 2},
+			},
+		},
+		{
+			name: "open position",
+			fills: []Fill{
+				{T: 1, Price: 10, Quantity: 1, Side: Buy},
+				{T: 2, Price: 11, Quantity: 1, Side: Buy},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		got := roundTrips(test.fills)
+		if len(got) != len(test.want) {
+			t.Errorf("%s: got %d round trips %+v, want %d", test.name, len(got), got, len(test.want))
+			continue
+		}
+		for i, trip := range got {
+			if trip != test.want[i] {
+				t.Errorf("%s: round trip %d = %+v, want %+v", test.name, i, trip, test.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestTradeMarkersSnap(t *testing.T) {
+	data := custplotter.TOHLCVs{
+		{T: 10, O: 1, H: 2, L: 1, C: 2},
+		{T: 20, O: 2, H: 3, L: 2, C: 3},
+		{T: 40, O: 3, H: 4, L: 3, C: 4},
+	}
+	fills := []custplotter.Fill{
+		{T: 33, Price: 3.5, Quantity: 1, Side: custplotter.Sell},
+		{T: 14, Price: 1.5, Quantity: 1, Side: custplotter.Buy},
+	}
+
+	markers, err := custplotter.NewTradeMarkers(data, fills)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if markers.Fills[0].T != 14 || markers.Fills[1].T != 33 {
+		t.Errorf("fills are not sorted by time: %v", markers.Fills)
+	}
+
+	xmin, xmax, ymin, ymax := markers.DataRange()
+	if xmin != 10 || xmax != 40 || ymin != 1.5 || ymax != 3.5 {
+		t.Errorf("DataRange() = %v, %v, %v, %v, want 10, 40, 1.5, 3.5", xmin, xmax, ymin, ymax)
+	}
+
+	fills[0].Quantity = -1
+	if _, err := custplotter.NewTradeMarkers(data, fills); err == nil {
+		t.Error("expected error for negative quantity")
+	}
+}
+
+func TestNewTradeMarkers(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// a long trade with two entries followed by a short trade
+	// which is opened by reversing the position
+	fills := []custplotter.Fill{
+		{T: testTOHLCVs[0].T, Price: testTOHLCVs[0].C, Quantity: 100, Side: custplotter.Buy},
+		{T: testTOHLCVs[3].T + 20, Price: testTOHLCVs[3].C, Quantity: 50, Side: custplotter.Buy},
+		{T: testTOHLCVs[11].T, Price: testTOHLCVs[11].H, Quantity: 250, Side: custplotter.Sell},
+		{T: testTOHLCVs[19].T, Price: testTOHLCVs[19].L + 0.5, Quantity: 100, Side: custplotter.Buy},
+	}
+
+	markers, err := custplotter.NewTradeMarkers(testTOHLCVs, fills)
+	if err != nil {
+		log.Panic(err)
+	}
+	markers.Connectors = true
+
+	p.Add(sticks, markers)
+
+	testFile := "testdata/trademarkers.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
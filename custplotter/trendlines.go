@@ -0,0 +1,139 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"encoding/json"
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Trendline is a line through two points given as time and price.
+// A trendline is a segment between the two points unless it is extended
+// to the left or right edge of the data canvas. A trendline that is only
+// extended to one side is a ray.
+type Trendline struct {
+	T1 float64 `json:"t1"`
+	P1 float64 `json:"p1"`
+	T2 float64 `json:"t2"`
+	P2 float64 `json:"p2"`
+
+	ExtendLeft  bool `json:"extendLeft,omitempty"`
+	ExtendRight bool `json:"extendRight,omitempty"`
+
+	// Channel contains the price offsets of lines parallel
+	// to the trendline, e. g. to draw a channel.
+	Channel []float64 `json:"channel,omitempty"`
+}
+
+// Trendlines implements the Plotter interface, drawing trendlines
+// and their parallel lines clipped against the data canvas.
+//
+// Trendlines implements json.Marshaler and json.Unmarshaler. Only the
+// lines are serialized, so that drawings can be saved and reloaded into
+// a plotter created with NewTrendlines.
+type Trendlines struct {
+	Lines []Trendline
+
+	// LineStyle is the style used to draw the trendlines.
+	draw.LineStyle
+
+	// ChannelLineStyle is the style used to draw the parallel lines.
+	ChannelLineStyle draw.LineStyle
+}
+
+// NewTrendlines creates a new trendline plotter for the given lines.
+func NewTrendlines(lines ...Trendline) (*Trendlines, error) {
+	for _, l := range lines {
+		if err := plotter.CheckFloats(l.T1, l.P1, l.T2, l.P2); err != nil {
+			return nil, err
+		}
+		if err := plotter.CheckFloats(l.Channel...); err != nil {
+			return nil, err
+		}
+	}
+
+	lineStyle := plotter.DefaultLineStyle
+	lineStyle.Color = color.RGBA{R: 0, G: 0, B: 196, A: 255}
+	channelLineStyle := lineStyle
+	channelLineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+
+	return &Trendlines{
+		Lines:            append([]Trendline(nil), lines...),
+		LineStyle:        lineStyle,
+		ChannelLineStyle: channelLineStyle,
+	}, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (tl *Trendlines) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Lines []Trendline `json:"lines"`
+	}{tl.Lines})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It replaces the lines and keeps the styles.
+func (tl *Trendlines) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Lines []Trendline `json:"lines"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	for _, l := range v.Lines {
+		if err := plotter.CheckFloats(l.T1, l.P1, l.T2, l.P2); err != nil {
+			return err
+		}
+	}
+	tl.Lines = v.Lines
+	return nil
+}
+
+// extend returns the end points of the line through a and b, where a is
+// left of b, extended to the left and right edge of c as requested.
+func extend(c draw.Canvas, a, b vg.Point, left, right bool) (vg.Point, vg.Point) {
+	if a.X == b.X {
+		return a, b
+	}
+	slope := (b.Y - a.Y) / (b.X - a.X)
+	if left && c.Min.X < a.X {
+		a = vg.Point{X: c.Min.X, Y: a.Y - (a.X-c.Min.X)*slope}
+	}
+	if right && c.Max.X > b.X {
+		b = vg.Point{X: c.Max.X, Y: b.Y + (c.Max.X-b.X)*slope}
+	}
+	return a, b
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (tl *Trendlines) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	for _, l := range tl.Lines {
+		t1, p1, t2, p2 := l.T1, l.P1, l.T2, l.P2
+		if t2 < t1 {
+			t1, p1, t2, p2 = t2, p2, t1, p1
+		}
+		if !canTransform(plt.Y, p1) || !canTransform(plt.Y, p2) {
+			continue
+		}
+
+		a, b := extend(c, vg.Point{X: trX(t1), Y: trY(p1)}, vg.Point{X: trX(t2), Y: trY(p2)}, l.ExtendLeft, l.ExtendRight)
+		c.StrokeLines(tl.LineStyle, c.ClipLinesXY([]vg.Point{a, b})...)
+
+		for _, offset := range l.Channel {
+			if !canTransform(plt.Y, p1+offset) || !canTransform(plt.Y, p2+offset) {
+				continue
+			}
+			a, b := extend(c, vg.Point{X: trX(t1), Y: trY(p1 + offset)}, vg.Point{X: trX(t2), Y: trY(p2 + offset)}, l.ExtendLeft, l.ExtendRight)
+			c.StrokeLines(tl.ChannelLineStyle, c.ClipLinesXY([]vg.Point{a, b})...)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"encoding/json"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestTrendlinesJSON(t *testing.T) {
+	lines := []custplotter.Trendline{
+		{T1: 1, P1: 100, T2: 5, P2: 104, ExtendRight: true, Channel: []float64{-2}},
+		{T1: 2, P1: 103, T2: 4, P2: 101},
+	}
+
+	tl, err := custplotter.NewTrendlines(lines...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(tl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"lines":[{"t1":1,"p1":100,"t2":5,"p2":104,"extendRight":true,"channel":[-2]},{"t1":2,"p1":103,"t2":4,"p2":101}]}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	reloaded, err := custplotter.NewTrendlines()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, reloaded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reloaded.Lines, lines) {
+		t.Errorf("reloaded lines = %v, want %v", reloaded.Lines, lines)
+	}
+	if reloaded.LineStyle.Width == 0 {
+		t.Error("line style was not kept")
+	}
+}
+
+func TestNewTrendlines(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	trendlines, err := custplotter.NewTrendlines(
+		// rising channel along the lows
+		custplotter.Trendline{
+			T1: testTOHLCVs[0].T, P1: testTOHLCVs[0].L,
+			T2: testTOHLCVs[8].T, P2: testTOHLCVs[8].L,
+			ExtendRight: true,
+			Channel:     []float64{2},
+		},
+		// falling segment along the highs
+		custplotter.Trendline{
+			T1: testTOHLCVs[13].T, P1: testTOHLCVs[13].H,
+			T2: testTOHLCVs[18].T, P2: testTOHLCVs[18].H,
+		},
+		// falling line extended to both sides
+		custplotter.Trendline{
+			T1: testTOHLCVs[6].T, P1: 104,
+			T2: testTOHLCVs[12].T, P2: 103,
+			ExtendLeft:  true,
+			ExtendRight: true,
+		},
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(sticks, trendlines)
+
+	testFile := "testdata/trendlines.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
@@ -27,6 +27,16 @@ type VBars struct {
 
 	// LineStyle is the style used to draw the bars.
 	draw.LineStyle
+
+	// PositiveOnly determines if DataRange ignores bars with non-positive
+	// volume and does not include zero, which cannot be shown on a log scale
+	// (plot.LogScale). On a log scale the bars start at the bottom of the
+	// data canvas and bars with non-positive volume are not drawn.
+	PositiveOnly bool
+
+	// Viewport, if not nil, restricts the bars which are drawn and
+	// covered by DataRange to the bars within the viewport.
+	Viewport *Viewport
 }
 
 // NewBars creates as new bar plotter for
@@ -57,8 +67,16 @@ func NewVBars(TOHLCV TOHLCVer) (*VBars, error) {
 func (bars *VBars) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
 	lineStyle := bars.LineStyle
+	logScale := isLogScale(plt.Y.Scale)
 
 	for _, TOHLCV := range bars.TOHLCVs {
+		if !bars.Viewport.Contains(TOHLCV.T) {
+			continue
+		}
+		if logScale && TOHLCV.V <= 0 {
+			continue
+		}
+
 		if TOHLCV.C >= TOHLCV.O {
 			lineStyle.Color = bars.ColorUp
 		} else {
@@ -68,7 +86,10 @@ func (bars *VBars) Plot(c draw.Canvas, plt *plot.Plot) {
 		// Transform the data
 		// to the corresponding drawing coordinate.
 		x := trX(TOHLCV.T)
-		y0 := trY(0)
+		y0 := c.Min.Y
+		if !logScale {
+			y0 = trY(0)
+		}
 		y := trY(TOHLCV.V)
 
 		bar := c.ClipLinesY([]vg.Point{{x, y0}, {x, y}})
@@ -84,7 +105,19 @@ func (bars *VBars) DataRange() (xmin, xmax, ymin, ymax float64) {
 	xmax = math.Inf(-1)
 	ymin = 0
 	ymax = math.Inf(-1)
+	if bars.PositiveOnly {
+		ymin = math.Inf(1)
+	}
 	for _, TOHLCV := range bars.TOHLCVs {
+		if !bars.Viewport.Contains(TOHLCV.T) {
+			continue
+		}
+		if bars.PositiveOnly {
+			if TOHLCV.V <= 0 {
+				continue
+			}
+			ymin = math.Min(ymin, TOHLCV.V)
+		}
 		xmin = math.Min(xmin, TOHLCV.T)
 		xmax = math.Max(xmax, TOHLCV.T)
 		ymax = math.Max(ymax, TOHLCV.V)
@@ -92,6 +125,29 @@ func (bars *VBars) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return
 }
 
+// WindowDataRange returns the Y range of the bars with T in [xmin, xmax]
+// like DataRange, e.g. to fit the Y axis to the bars within a window.
+func (bars *VBars) WindowDataRange(xmin, xmax float64) (ymin, ymax float64) {
+	ymin = 0
+	ymax = math.Inf(-1)
+	if bars.PositiveOnly {
+		ymin = math.Inf(1)
+	}
+	for _, TOHLCV := range bars.TOHLCVs {
+		if TOHLCV.T < xmin || TOHLCV.T > xmax || !bars.Viewport.Contains(TOHLCV.T) {
+			continue
+		}
+		if bars.PositiveOnly {
+			if TOHLCV.V <= 0 {
+				continue
+			}
+			ymin = math.Min(ymin, TOHLCV.V)
+		}
+		ymax = math.Max(ymax, TOHLCV.V)
+	}
+	return
+}
+
 // GlyphBoxes implements the GlyphBoxes method
 // of the plot.GlyphBoxer interface.
 // We just return 2 glyph boxes at xmin, ymin and xmax, ymax
@@ -117,3 +173,27 @@ func (bars *VBars) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 
 	return boxes
 }
+
+// Thumbnail implements the Thumbnail method
+// of the plot.Thumbnailer interface.
+// It draws a miniature up bar next to a down bar.
+func (bars *VBars) Thumbnail(c *draw.Canvas) {
+	w := c.Max.X - c.Min.X
+	h := c.Max.Y - c.Min.Y
+
+	// volume relative to the height
+	vs := []struct {
+		x, v  float64
+		color color.Color
+	}{
+		{x: 1.0 / 3, v: 0.6, color: bars.ColorUp},
+		{x: 2.0 / 3, v: 0.9, color: bars.ColorDown},
+	}
+
+	lineStyle := bars.LineStyle
+	for _, bar := range vs {
+		lineStyle.Color = bar.color
+		x := c.Min.X + vg.Length(bar.x)*w
+		c.StrokeLine2(lineStyle, x, c.Min.Y, x, c.Min.Y+vg.Length(bar.v)*h)
+	}
+}
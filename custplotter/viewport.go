@@ -0,0 +1,75 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// errViewport is returned for empty or invalid viewports.
+var errViewport = errors.New("custplotter: invalid viewport")
+
+// Viewport is a time window over a series of bars, e.g. the last 120 bars
+// or the bars from date A to date B. Candlesticks, OHLCBars and VBars with
+// a Viewport only draw the bars within the window and their DataRange only
+// covers these bars, so that the axes of the plot fit the visible bars.
+// The overlays of this package, e.g. ZigZag or TradeMarkers, have a Viewport
+// as well. The same Viewport can be shared by all plotters of a chart.
+//
+// A nil *Viewport contains all bars.
+type Viewport struct {
+	// From and To are the first and the last time within the window.
+	From, To float64
+}
+
+// NewViewport creates a new viewport from time from to time to.
+func NewViewport(from, to float64) (*Viewport, error) {
+	if err := plotter.CheckFloats(from, to); err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, errViewport
+	}
+	return &Viewport{From: from, To: to}, nil
+}
+
+// LastBars creates a new viewport containing the last n bars of data.
+func LastBars(data TOHLCVer, n int) (*Viewport, error) {
+	l := data.Len()
+	if n < 1 || l == 0 {
+		return nil, errViewport
+	}
+	if n > l {
+		n = l
+	}
+	from, _, _, _, _, _ := data.TOHLCV(l - n)
+	to, _, _, _, _, _ := data.TOHLCV(l - 1)
+	return NewViewport(from, to)
+}
+
+// Contains returns true if t is within the viewport.
+// A nil viewport contains every t.
+func (vp *Viewport) Contains(t float64) bool {
+	return vp == nil || (t >= vp.From && t <= vp.To)
+}
+
+// XYs returns the points of data with X within the viewport, e.g. the
+// values of an indicator to be overlaid by a plotter.Line, so that the
+// Y range of the overlay fits the visible bars as well. Points with
+// a NaN Y, e.g. of an indicator which is not yet computed, are skipped.
+func (vp *Viewport) XYs(data plotter.XYer) plotter.XYs {
+	var xys plotter.XYs
+	for i := 0; i < data.Len(); i++ {
+		x, y := data.XY(i)
+		if !vp.Contains(x) || math.IsNaN(y) {
+			continue
+		}
+		xys = append(xys, plotter.XY{X: x, Y: y})
+	}
+	return xys
+}
@@ -0,0 +1,128 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestViewport(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	vp, err := custplotter.LastBars(testTOHLCVs, 10)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+	sticks.Viewport = vp
+
+	xmin, xmax, ymin, ymax := sticks.DataRange()
+	first, last := testTOHLCVs[len(testTOHLCVs)-10], testTOHLCVs[len(testTOHLCVs)-1]
+	if xmin != first.T || xmax != last.T {
+		t.Errorf("unexpected X range [%v, %v], want [%v, %v]", xmin, xmax, first.T, last.T)
+	}
+	wantMin, wantMax := math.Inf(1), math.Inf(-1)
+	for _, TOHLCV := range testTOHLCVs[len(testTOHLCVs)-10:] {
+		wantMin = math.Min(wantMin, TOHLCV.L)
+		wantMax = math.Max(wantMax, TOHLCV.H)
+	}
+	if ymin != wantMin || ymax != wantMax {
+		t.Errorf("unexpected Y range [%v, %v], want [%v, %v]", ymin, ymax, wantMin, wantMax)
+	}
+
+	sma, err := custplotter.NewSMA(3)
+	if err != nil {
+		log.Panic(err)
+	}
+	values := custplotter.IndicatorValues(sma, testTOHLCVs)
+	xys := make(plotter.XYs, len(values))
+	for i, v := range values {
+		xys[i].X = testTOHLCVs[i].T
+		xys[i].Y = v
+	}
+	line, err := plotter.NewLine(vp.XYs(xys))
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(sticks, line)
+
+	testFile := "testdata/viewport.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
+
+func TestNewViewport(t *testing.T) {
+	if _, err := custplotter.NewViewport(2, 1); err == nil {
+		t.Error("expected error for from > to")
+	}
+	if _, err := custplotter.NewViewport(math.NaN(), 1); err == nil {
+		t.Error("expected error for NaN")
+	}
+	if _, err := custplotter.LastBars(custplotter.TOHLCVs{}, 10); err == nil {
+		t.Error("expected error for empty data")
+	}
+
+	var vp *custplotter.Viewport
+	if !vp.Contains(42) {
+		t.Error("nil viewport does not contain all times")
+	}
+}
+
+func TestViewportOverlay(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	vp, err := custplotter.LastBars(testTOHLCVs, 5)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+	sticks.Viewport = vp
+
+	zigzag, err := custplotter.NewZigZag(custplotter.ZigZagPercent(testTOHLCVs, 1))
+	if err != nil {
+		log.Panic(err)
+	}
+	zigzag.Viewport = vp
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(sticks, zigzag)
+
+	xmin, xmax, ymin, ymax := sticks.DataRange()
+	if p.X.Min != xmin || p.X.Max != xmax {
+		t.Errorf("X range [%v, %v] of the plot exceeds the viewport [%v, %v]", p.X.Min, p.X.Max, xmin, xmax)
+	}
+	if p.Y.Min < ymin || p.Y.Max > ymax {
+		t.Errorf("Y range [%v, %v] of the plot exceeds the visible bars [%v, %v]", p.Y.Min, p.Y.Max, ymin, ymax)
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// SwingPoint is a swing high or swing low detected by the zigzag algorithm.
+type SwingPoint struct {
+	// Index is the index of the bar of the swing point.
+	Index int
+
+	// T is the time and Price the high or low of the bar.
+	T, Price float64
+
+	// High is true for swing highs and false for swing lows.
+	High bool
+
+	// Change is the relative price change from the previous
+	// swing point, e. g. 0.05 for +5%. It is zero for the first one.
+	Change float64
+
+	// Confirmed is false for the last swing point if the price has not
+	// yet reversed by the threshold since it was reached.
+	Confirmed bool
+}
+
+// ZigZagPercent detects the swing points of data. A swing point is confirmed
+// when the price reverses by at least percent percent from it.
+func ZigZagPercent(data TOHLCVer, percent float64) []SwingPoint {
+	return zigZag(data, func(i int, extreme float64) float64 {
+		return math.Abs(extreme) * percent / 100
+	})
+}
+
+// ZigZagATR detects the swing points of data. A swing point is confirmed
+// when the price reverses by at least multiplier times the average true
+// range over period bars. No swing point is confirmed before the average
+// true range is available.
+func ZigZagATR(data TOHLCVer, period int, multiplier float64) ([]SwingPoint, error) {
+	atr, err := NewATR(period)
+	if err != nil {
+		return nil, err
+	}
+	atrs := IndicatorValues(atr, data)
+	return zigZag(data, func(i int, extreme float64) float64 {
+		return multiplier * atrs[i]
+	}), nil
+}
+
+// zigZag detects the swing points of data. threshold returns the minimum
+// reversal at bar i from the extreme price reached so far.
+func zigZag(data TOHLCVer, threshold func(i int, extreme float64) float64) []SwingPoint {
+	n := data.Len()
+	if n == 0 {
+		return nil
+	}
+
+	highs := make([]float64, n)
+	lows := make([]float64, n)
+	times := make([]float64, n)
+	for i := range highs {
+		times[i], _, highs[i], lows[i], _, _ = data.TOHLCV(i)
+	}
+
+	var points []SwingPoint
+	add := func(i int, high, confirmed bool) {
+		p := SwingPoint{Index: i, T: times[i], Price: lows[i], High: high, Confirmed: confirmed}
+		if high {
+			p.Price = highs[i]
+		}
+		if len(points) > 0 {
+			prev := points[len(points)-1].Price
+			p.Change = (p.Price - prev) / prev
+		}
+		points = append(points, p)
+	}
+
+	dir := 0 // 1: up, -1: down, 0: not yet known
+	hi, lo := 0, 0
+	for i := 1; i < n; i++ {
+		switch dir {
+		case 0:
+			if highs[i] > highs[hi] {
+				hi = i
+			}
+			if lows[i] < lows[lo] {
+				lo = i
+			}
+			if highs[hi]-lows[lo] >= threshold(i, lows[lo]) {
+				if lo < hi {
+					add(lo, false, true)
+					dir = 1
+				} else if hi < lo {
+					add(hi, true, true)
+					dir = -1
+				}
+			}
+		case 1:
+			if highs[i] >= highs[hi] {
+				hi = i
+			} else if highs[hi]-lows[i] >= threshold(i, highs[hi]) {
+				add(hi, true, true)
+				dir = -1
+				// the threshold may have been larger at a lower low
+				// since the swing high, so the swing low is the lowest
+				// low and not necessarily the one of bar i
+				lo = hi + 1
+				for j := lo + 1; j <= i; j++ {
+					if lows[j] <= lows[lo] {
+						lo = j
+					}
+				}
+			}
+		case -1:
+			if lows[i] <= lows[lo] {
+				lo = i
+			} else if highs[i]-lows[lo] >= threshold(i, lows[lo]) {
+				add(lo, false, true)
+				dir = 1
+				hi = lo + 1
+				for j := hi + 1; j <= i; j++ {
+					if highs[j] >= highs[hi] {
+						hi = j
+					}
+				}
+			}
+		}
+	}
+
+	switch dir {
+	case 1:
+		add(hi, true, false)
+	case -1:
+		add(lo, false, false)
+	}
+	return points
+}
+
+// ZigZag implements the Plotter interface, drawing lines
+// connecting swing points and optionally labels at the swing points.
+type ZigZag struct {
+	Points []SwingPoint
+
+	// LineStyle is the style used to draw the lines.
+	draw.LineStyle
+
+	// Labels determines if the swing points are labeled
+	// with their price and their percentage move.
+	Labels bool
+
+	// TextStyle is the style of the labels.
+	TextStyle draw.TextStyle
+
+	// PositiveOnly determines if DataRange ignores swing points with
+	// non-positive prices, which cannot be shown on a log scale
+	// (plot.LogScale). Such points are never drawn on a log scale.
+	PositiveOnly bool
+
+	// Viewport, if not nil, restricts the swing points which are
+	// drawn and covered by DataRange to the ones within the viewport.
+	Viewport *Viewport
+}
+
+// NewZigZag creates a new zigzag plotter for the given swing points.
+func NewZigZag(points []SwingPoint) (*ZigZag, error) {
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(8))
+	if err != nil {
+		return nil, err
+	}
+
+	lineStyle := plotter.DefaultLineStyle
+	lineStyle.Color = color.RGBA{R: 0, G: 0, B: 196, A: 255}
+
+	return &ZigZag{
+		Points:    append([]SwingPoint(nil), points...),
+		LineStyle: lineStyle,
+		TextStyle: draw.TextStyle{
+			Color:  lineStyle.Color,
+			Font:   font,
+			XAlign: draw.XCenter,
+		},
+	}, nil
+}
+
+// label returns the text and the style of the label of p.
+func (zz *ZigZag) label(p SwingPoint) (string, draw.TextStyle) {
+	sty := zz.TextStyle
+	sty.YAlign = draw.YTop
+	if p.High {
+		sty.YAlign = draw.YBottom
+	}
+	return fmt.Sprintf("%.2f\n%+.1f%%", p.Price, p.Change*100), sty
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (zz *ZigZag) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	// points at or below zero cannot be shown on a log scale
+	var points []SwingPoint
+	for _, p := range zz.Points {
+		if canTransform(plt.Y, p.Price) && zz.Viewport.Contains(p.T) {
+			points = append(points, p)
+		}
+	}
+
+	line := make([]vg.Point, len(points))
+	for i, p := range points {
+		line[i] = vg.Point{X: trX(p.T), Y: trY(p.Price)}
+	}
+	c.StrokeLines(zz.LineStyle, c.ClipLinesXY(line)...)
+
+	if !zz.Labels {
+		return
+	}
+	for i, p := range points {
+		if !c.Contains(line[i]) {
+			continue
+		}
+		txt, sty := zz.label(p)
+		c.FillText(sty, line[i], txt)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (zz *ZigZag) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin = math.Inf(1)
+	xmax = math.Inf(-1)
+	ymin = math.Inf(1)
+	ymax = math.Inf(-1)
+	for _, p := range zz.Points {
+		if !zz.Viewport.Contains(p.T) || zz.PositiveOnly && p.Price <= 0 {
+			continue
+		}
+		xmin = math.Min(xmin, p.T)
+		xmax = math.Max(xmax, p.T)
+		ymin = math.Min(ymin, p.Price)
+		ymax = math.Max(ymax, p.Price)
+	}
+	return
+}
+
+// WindowDataRange returns the Y range of the
+// swing points with T in [xmin, xmax] like DataRange.
+func (zz *ZigZag) WindowDataRange(xmin, xmax float64) (ymin, ymax float64) {
+	ymin = math.Inf(1)
+	ymax = math.Inf(-1)
+	for _, p := range zz.Points {
+		if p.T < xmin || p.T > xmax || !zz.Viewport.Contains(p.T) || zz.PositiveOnly && p.Price <= 0 {
+			continue
+		}
+		ymin = math.Min(ymin, p.Price)
+		ymax = math.Max(ymax, p.Price)
+	}
+	return
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+// If labels are drawn then there is a glyph box for each label.
+func (zz *ZigZag) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if !zz.Labels {
+		return nil
+	}
+
+	boxes := make([]plot.GlyphBox, 0, len(zz.Points))
+	for _, p := range zz.Points {
+		if !canTransform(plt.Y, p.Price) || !zz.Viewport.Contains(p.T) {
+			continue
+		}
+		txt, sty := zz.label(p)
+		boxes = append(boxes, plot.GlyphBox{
+			X:         plt.X.Norm(p.T),
+			Y:         plt.Y.Norm(p.Price),
+			Rectangle: sty.Rectangle(txt),
+		})
+	}
+	return boxes
+}
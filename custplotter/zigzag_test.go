@@ -0,0 +1,132 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package custplotter_test
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/custplotter/internal"
+	"gonum.org/v1/plot"
+)
+
+func TestZigZagPercent(t *testing.T) {
+	data := custplotter.TOHLCVs{
+		{T: 0, O: 100, H: 101, L: 99, C: 100},
+		{T: 1, O: 100, H: 106, L: 100, C: 105}, // swing high
+		{T: 2, O: 105, H: 105, L: 100, C: 101},
+		{T: 3, O: 101, H: 101, L: 95, C: 96}, // swing low
+		{T: 4, O: 96, H: 99, L: 96, C: 98},
+		{T: 5, O: 98, H: 102, L: 97, C: 101}, // last, unconfirmed high
+	}
+
+	points := custplotter.ZigZagPercent(data, 5)
+
+	want := []custplotter.SwingPoint{
+		{Index: 0, T: 0, Price: 99, High: false, Confirmed: true},
+		{Index: 1, T: 1, Price: 106, High: true, Change: 7.0 / 99, Confirmed: true},
+		{Index: 3, T: 3, Price: 95, High: false, Change: -11.0 / 106, Confirmed: true},
+		{Index: 5, T: 5, Price: 102, High: true, Change: 7.0 / 95, Confirmed: false},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("got %d swing points %v, want %d", len(points), points, len(want))
+	}
+	for i, p := range points {
+		w := want[i]
+		if p.Index != w.Index || p.T != w.T || p.Price != w.Price || p.High != w.High ||
+			p.Confirmed != w.Confirmed || math.Abs(p.Change-w.Change) > 1e-9 {
+			t.Errorf("swing point %d = %+v, want %+v", i, p, w)
+		}
+	}
+}
+
+func TestZigZagATR(t *testing.T) {
+	data := internal.CreateTOHLCVTestData()
+
+	points, err := custplotter.ZigZagATR(data, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) < 2 {
+		t.Fatalf("got %d swing points, want at least 2", len(points))
+	}
+	for i := 1; i < len(points); i++ {
+		if points[i].High == points[i-1].High {
+			t.Errorf("swing points %d and %d are both highs or both lows", i-1, i)
+		}
+		if points[i].Index <= points[i-1].Index {
+			t.Errorf("swing points %d and %d are not in order", i-1, i)
+		}
+	}
+
+	if _, err := custplotter.ZigZagATR(data, 0, 2); err == nil {
+		t.Error("expected error for ATR period 0")
+	}
+}
+
+func TestZigZagATRFalling(t *testing.T) {
+	data := custplotter.TOHLCVs{
+		{T: 0, O: 100, H: 101, L: 99, C: 100},
+		{T: 1, O: 101, H: 103, L: 101, C: 103},
+		{T: 2, O: 103, H: 105, L: 103, C: 105}, // swing high
+		{T: 3, O: 104, H: 104, L: 95, C: 96},   // swing low with a large ATR
+		{T: 4, O: 97, H: 97.5, L: 97, C: 97.5}, // higher low confirming the reversal
+	}
+
+	points, err := custplotter.ZigZagATR(data, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []custplotter.SwingPoint{
+		{Index: 0, T: 0, Price: 99, High: false, Confirmed: true},
+		{Index: 2, T: 2, Price: 105, High: true, Change: 6.0 / 99, Confirmed: true},
+		{Index: 3, T: 3, Price: 95, High: false, Change: -10.0 / 105, Confirmed: false},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("got %d swing points %v, want %d", len(points), points, len(want))
+	}
+	for i, p := range points {
+		w := want[i]
+		if p.Index != w.Index || p.T != w.T || p.Price != w.Price || p.High != w.High ||
+			p.Confirmed != w.Confirmed || math.Abs(p.Change-w.Change) > 1e-9 {
+			t.Errorf("swing point %d = %+v, want %+v", i, p, w)
+		}
+	}
+}
+
+func TestNewZigZag(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.X.Tick.Marker = plot.TimeTicks{Format: "2006-01-02\n15:04:05"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	zigzag, err := custplotter.NewZigZag(custplotter.ZigZagPercent(testTOHLCVs, 2))
+	if err != nil {
+		log.Panic(err)
+	}
+	zigzag.Labels = true
+
+	p.Add(sticks, zigzag)
+
+	testFile := "testdata/zigzag.png"
+	err = p.Save(180, 100, testFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
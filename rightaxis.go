@@ -0,0 +1,296 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// RightAxis implements the plot.Plotter interface, drawing a vertical axis
+// along the right side of the data canvas.
+//
+// The space required by the axis is reserved by glyph boxes to the right of
+// the data canvas. Thus the data canvases of plots with a RightAxis are
+// aligned by Table.Align just like the ones of plots without.
+//
+// A mirrored axis (see NewRightAxis) uses the range and the scale of the Y
+// axis of the plot, e.g. to show the prices on both sides or to show the
+// prices on the left and the percent changes (see custplotter.PercentScale)
+// on the right. A secondary axis (see NewSecondaryAxis) has its own range
+// and draws its own plotters, e.g. to overlay a series with a different
+// unit. The plot's Y axis can be hidden by calling HideY of the plot to
+// show the prices on the right only.
+type RightAxis struct {
+	// Axis holds the styles of the axis. Min, Max and Scale are
+	// ignored if Mirror is set. Tick.Label.XAlign should be draw.XLeft.
+	plot.Axis
+
+	// Mirror determines if Min, Max and Scale
+	// of the Y axis of the plot are used.
+	Mirror bool
+
+	plotters []plot.Plotter
+}
+
+// newRightAxis returns a right axis with
+// the default styles of gonum.org/v1/plot.
+func newRightAxis() (*RightAxis, error) {
+	labelFont, err := vg.MakeFont(plot.DefaultFont, vg.Points(12))
+	if err != nil {
+		return nil, err
+	}
+
+	tickFont, err := vg.MakeFont(plot.DefaultFont, vg.Points(10))
+	if err != nil {
+		return nil, err
+	}
+
+	a := plot.Axis{
+		Min: math.Inf(+1),
+		Max: math.Inf(-1),
+		LineStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(0.5),
+		},
+		Padding: vg.Points(5),
+		Scale:   plot.LinearScale{},
+	}
+	a.Label.TextStyle = draw.TextStyle{
+		Color:  color.Black,
+		Font:   labelFont,
+		XAlign: draw.XCenter,
+		YAlign: draw.YBottom,
+	}
+	a.Tick.Label = draw.TextStyle{
+		Color:  color.Black,
+		Font:   tickFont,
+		XAlign: draw.XLeft,
+		YAlign: draw.YCenter,
+	}
+	a.Tick.LineStyle = draw.LineStyle{
+		Color: color.Black,
+		Width: vg.Points(0.5),
+	}
+	a.Tick.Length = vg.Points(8)
+	a.Tick.Marker = plot.DefaultTicks{}
+
+	return &RightAxis{Axis: a}, nil
+}
+
+// NewRightAxis creates a new right axis mirroring
+// the range and the scale of the Y axis of the plot.
+func NewRightAxis() (*RightAxis, error) {
+	ra, err := newRightAxis()
+	if err != nil {
+		return nil, err
+	}
+	ra.Mirror = true
+	return ra, nil
+}
+
+// NewSecondaryAxis creates a new right axis with its own range which
+// draws the given plotters. The range of the axis is the union of the
+// Y ranges of the plotters.
+//
+// The axis must be added to the plot after all plotters were added
+// to the axis, so that the X ranges of the plotters are taken into
+// account by the plot.
+func NewSecondaryAxis(ps ...plot.Plotter) (*RightAxis, error) {
+	ra, err := newRightAxis()
+	if err != nil {
+		return nil, err
+	}
+	ra.Add(ps...)
+	return ra, nil
+}
+
+// Add adds plotters drawn against the range of the axis and
+// extends the range of the axis to the Y ranges of the plotters.
+func (ra *RightAxis) Add(ps ...plot.Plotter) {
+	for _, d := range ps {
+		if x, ok := d.(plot.DataRanger); ok {
+			_, _, ymin, ymax := x.DataRange()
+			ra.Min = math.Min(ra.Min, ymin)
+			ra.Max = math.Max(ra.Max, ymax)
+		}
+	}
+	ra.plotters = append(ra.plotters, ps...)
+}
+
+// axis returns the axis as it is drawn for the plot plt.
+func (ra *RightAxis) axis(plt *plot.Plot) plot.Axis {
+	a := ra.Axis
+	if ra.Mirror {
+		a.Min, a.Max, a.Scale = plt.Y.Min, plt.Y.Max, plt.Y.Scale
+	}
+
+	// same as the unexported sanitizeRange of plot.Axis
+	if math.IsInf(a.Min, 0) {
+		a.Min = 0
+	}
+	if math.IsInf(a.Max, 0) {
+		a.Max = 0
+	}
+	if a.Min > a.Max {
+		a.Min, a.Max = a.Max, a.Min
+	}
+	if a.Min == a.Max {
+		a.Min--
+		a.Max++
+	}
+	return a
+}
+
+// secondary returns a copy of plt with the Y axis replaced by a.
+func secondary(plt *plot.Plot, a plot.Axis) *plot.Plot {
+	cpy := *plt
+	cpy.Y = a
+	return &cpy
+}
+
+// tickLabelWidth returns the width of the widest major tick label.
+func tickLabelWidth(sty draw.TextStyle, ticks []plot.Tick) vg.Length {
+	var w vg.Length
+	for _, t := range ticks {
+		if t.IsMinor() {
+			continue
+		}
+		w = vg.Length(math.Max(float64(w), float64(sty.Width(t.Label))))
+	}
+	return w
+}
+
+// size returns the width of the axis a including its padding.
+func size(a plot.Axis) vg.Length {
+	w := a.Padding + a.Width/2
+
+	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	if len(marks) > 0 {
+		if a.Tick.Width > 0 && a.Tick.Length > 0 {
+			w += a.Tick.Length
+		}
+		if lw := tickLabelWidth(a.Tick.Label, marks); lw > 0 {
+			w += a.Tick.Label.Width(" ") + lw
+		}
+	}
+
+	if a.Label.Text != "" {
+		w += a.Label.Height(a.Label.Text)
+	}
+	return w
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+// The axis is drawn to the right of the data canvas into the
+// space reserved by GlyphBoxes.
+func (ra *RightAxis) Plot(c draw.Canvas, plt *plot.Plot) {
+	a := ra.axis(plt)
+
+	if len(ra.plotters) > 0 {
+		sec := secondary(plt, a)
+		for _, d := range ra.plotters {
+			d.Plot(c, sec)
+		}
+	}
+
+	x := c.Max.X + a.Padding
+	c.StrokeLine2(a.LineStyle, x, c.Min.Y, x, c.Max.Y)
+
+	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	if a.Tick.Width > 0 && a.Tick.Length > 0 && len(marks) > 0 {
+		for _, t := range marks {
+			y := c.Y(a.Norm(t.Value))
+			if !c.ContainsY(y) {
+				continue
+			}
+			length := a.Tick.Length
+			if t.IsMinor() {
+				length /= 2
+			}
+			c.StrokeLine2(a.Tick.LineStyle, x, y, x+length, y)
+		}
+		x += a.Tick.Length
+	}
+
+	if lw := tickLabelWidth(a.Tick.Label, marks); lw > 0 {
+		x += a.Tick.Label.Width(" ")
+		for _, t := range marks {
+			y := c.Y(a.Norm(t.Value))
+			if !c.ContainsY(y) || t.IsMinor() {
+				continue
+			}
+			c.FillText(a.Tick.Label, vg.Point{X: x, Y: y}, t.Label)
+		}
+		x += lw
+	}
+
+	if a.Label.Text != "" {
+		sty := a.Label.TextStyle
+		sty.Rotation -= math.Pi / 2
+		c.FillText(sty, vg.Point{X: x, Y: c.Center().Y}, a.Label.Text)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+// It returns the X range of the plotters of the axis
+// and does not change the range of the plot's Y axis.
+func (ra *RightAxis) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, d := range ra.plotters {
+		if x, ok := d.(plot.DataRanger); ok {
+			dxmin, dxmax, _, _ := x.DataRange()
+			xmin = math.Min(xmin, dxmin)
+			xmax = math.Max(xmax, dxmax)
+		}
+	}
+	return
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+// The glyph boxes reserve the space for the axis to the right
+// of the data canvas and include the glyph boxes of the plotters
+// of the axis.
+func (ra *RightAxis) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	a := ra.axis(plt)
+	w := size(a)
+
+	boxes := []plot.GlyphBox{{
+		X:         1,
+		Y:         0.5,
+		Rectangle: vg.Rectangle{Max: vg.Point{X: w}},
+	}}
+	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+		if t.IsMinor() {
+			continue
+		}
+		h := a.Tick.Label.Height(t.Label)
+		boxes = append(boxes, plot.GlyphBox{
+			X: 1,
+			Y: a.Norm(t.Value),
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{Y: -h / 2},
+				Max: vg.Point{X: w, Y: h / 2},
+			},
+		})
+	}
+
+	if len(ra.plotters) > 0 {
+		sec := secondary(plt, a)
+		for _, d := range ra.plotters {
+			if gb, ok := d.(plot.GlyphBoxer); ok {
+				boxes = append(boxes, gb.GlyphBoxes(sec)...)
+			}
+		}
+	}
+	return boxes
+}
@@ -0,0 +1,111 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"os"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/internal"
+)
+
+func TestRightAxis(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	// prices on the left and percent changes on the right
+	p1, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p1.X.Tick.Marker = plot.TimeTicks{Format: "15:04"}
+
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+
+	percent, err := NewRightAxis()
+	if err != nil {
+		panic(err)
+	}
+	percent.Tick.Marker = custplotter.PercentScale{Ref: testTOHLCVs[0].C}
+	percent.Label.Text = "Change"
+
+	p1.Add(sticks, percent)
+
+	// volume on the left and the cumulated volume on a secondary axis
+	p2, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p2.X.Tick.Marker = plot.TimeTicks{Format: "15:04"}
+
+	vbars, err := custplotter.NewVBars(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+
+	cumulated := make(plotter.XYs, len(testTOHLCVs))
+	var sum float64
+	for i, b := range testTOHLCVs {
+		sum += b.V
+		cumulated[i].X, cumulated[i].Y = b.T, sum
+	}
+	line, err := plotter.NewLine(cumulated)
+	if err != nil {
+		panic(err)
+	}
+	line.Color = plotter.DefaultGlyphStyle.Color
+
+	secondary, err := NewSecondaryAxis(line)
+	if err != nil {
+		panic(err)
+	}
+
+	p2.Add(vbars, secondary)
+
+	UniteAxisRanges([]*plot.Axis{&p1.X, &p2.X})
+
+	table := Table{
+		RowHeights: []float64{2, 1},
+		ColWidths:  []float64{1},
+		PadTop:     2,
+		PadBottom:  2,
+		PadLeft:    2,
+		PadRight:   2,
+	}
+
+	img := vgimg.New(vg.Points(250), vg.Points(200))
+	dc := draw.New(img)
+
+	plots := [][]*plot.Plot{{p1}, {p2}}
+	canvases := table.Align(plots, dc)
+	p1.Draw(canvases[0][0])
+	p2.Draw(canvases[1][0])
+
+	if dc1, dc2 := p1.DataCanvas(canvases[0][0]), p2.DataCanvas(canvases[1][0]); dc1.Max.X != dc2.Max.X {
+		t.Errorf("right edges of the data canvases differ: %v != %v", dc1.Max.X, dc2.Max.X)
+	}
+
+	testFile := "testdata/rightaxis.png"
+	w, err := os.Create(testFile)
+	if err != nil {
+		panic(err)
+	}
+
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err := png.WriteTo(w); err != nil {
+		panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
@@ -0,0 +1,181 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Size is the size of a row or a column of a Table.
+//
+// An absolute size is the total length of the row or column including
+// the space needed by the axes of its plots. An Auto size is the length
+// of the largest Element in the row or column. The space remaining after
+// subtracting the absolute and Auto sizes is distributed among the rows
+// or columns with relative sizes according to their weights.
+type Size struct {
+	// Rel is the relative weight. It is used if Abs is 0 and Auto is false.
+	Rel float64
+
+	// Abs is the absolute length.
+	Abs vg.Length
+
+	// Auto determines if the size fits the elements.
+	Auto bool
+}
+
+// Rel returns a relative size with the given weight.
+func Rel(weight float64) Size {
+	return Size{Rel: weight}
+}
+
+// Abs returns an absolute size with the given length.
+func Abs(length vg.Length) Size {
+	return Size{Abs: length}
+}
+
+// Auto returns a size fitting the elements of the row or column.
+func Auto() Size {
+	return Size{Auto: true}
+}
+
+// relSizes returns relative sizes with the given weights.
+func relSizes(weights []float64) []Size {
+	sizes := make([]Size, len(weights))
+	for i, w := range weights {
+		sizes[i] = Rel(w)
+	}
+	return sizes
+}
+
+// rowSizes returns RowSizes if set and the RowHeights otherwise.
+func (tab Table) rowSizes() []Size {
+	if tab.RowSizes != nil {
+		return tab.RowSizes
+	}
+	return relSizes(tab.RowHeights)
+}
+
+// colSizes returns ColSizes if set and the ColWidths otherwise.
+func (tab Table) colSizes() []Size {
+	if tab.ColSizes != nil {
+		return tab.ColSizes
+	}
+	return relSizes(tab.ColWidths)
+}
+
+// lengths returns the lengths of the data areas of rows or columns with
+// the given sizes. natural are the lengths used for Auto sizes, spacing are
+// the lengths around the data areas and available is the total length of
+// the rows or columns.
+func lengths(sizes []Size, natural, spacing []float64, available float64) []float64 {
+	ls := make([]float64, len(sizes))
+	remaining := available
+	var sumRel float64
+	for i, s := range sizes {
+		remaining -= spacing[i]
+		switch {
+		case s.Auto:
+			ls[i] = natural[i]
+		case s.Abs != 0:
+			ls[i] = math.Max(float64(s.Abs)-spacing[i], 0)
+		default:
+			sumRel += s.Rel
+			continue
+		}
+		remaining -= ls[i]
+	}
+
+	if sumRel > 0 {
+		remaining = math.Max(remaining, 0)
+		for i, s := range sizes {
+			if !s.Auto && s.Abs == 0 {
+				ls[i] = remaining * s.Rel / sumRel
+			}
+		}
+	}
+	return ls
+}
+
+// Element is the content of a cell that is not a plot, e.g. a title or
+// a legend. Rows and columns with Auto size fit the sizes of their elements.
+type Element interface {
+	// Size returns the natural size of the element.
+	Size() (width, height vg.Length)
+
+	// Draw draws the element into the data area of its cell.
+	Draw(c draw.Canvas)
+}
+
+// Title is an Element drawing a text centered in its cell.
+type Title struct {
+	Text string
+
+	// TextStyle is the style of the text.
+	draw.TextStyle
+
+	// Padding is the padding around the text.
+	Padding vg.Length
+}
+
+// NewTitle creates a new title with the given text.
+func NewTitle(text string) (*Title, error) {
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(12))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Title{
+		Text: text,
+		TextStyle: draw.TextStyle{
+			Color:  color.Black,
+			Font:   font,
+			XAlign: draw.XCenter,
+			YAlign: draw.YCenter,
+		},
+		Padding: vg.Points(2),
+	}, nil
+}
+
+// Size implements the Size method of the Element interface.
+func (t *Title) Size() (width, height vg.Length) {
+	return t.Width(t.Text) + 2*t.Padding, t.Height(t.Text) + 2*t.Padding
+}
+
+// Draw implements the Draw method of the Element interface.
+func (t *Title) Draw(c draw.Canvas) {
+	c.FillText(t.TextStyle, c.Center(), t.Text)
+}
+
+// Legend is an Element drawing a legend in its cell, e.g. a legend
+// shared by the plots of a table.
+type Legend struct {
+	plot.Legend
+}
+
+// NewLegend creates a new empty legend.
+func NewLegend() (*Legend, error) {
+	l, err := plot.NewLegend()
+	if err != nil {
+		return nil, err
+	}
+	return &Legend{Legend: l}, nil
+}
+
+// Size implements the Size method of the Element interface.
+func (l *Legend) Size() (width, height vg.Length) {
+	r := l.Rectangle(draw.Canvas{})
+	return r.Max.X - r.Min.X, r.Max.Y - r.Min.Y
+}
+
+// Draw implements the Draw method of the Element interface.
+func (l *Legend) Draw(c draw.Canvas) {
+	l.Legend.Draw(c)
+}
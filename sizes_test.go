@@ -0,0 +1,166 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"math"
+	"os"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/internal"
+)
+
+func TestLengths(t *testing.T) {
+	sizes := []Size{Rel(2), Abs(30), Auto(), Rel(1)}
+	natural := []float64{0, 0, 12, 0}
+	spacing := []float64{5, 10, 0, 5}
+
+	// 100 - 20 spacing - 20 absolute data - 12 auto = 48 for the relative sizes
+	got := lengths(sizes, natural, spacing, 100)
+	want := []float64{32, 20, 12, 16}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lengths() = %v, want %v", got, want)
+	}
+}
+
+func TestSizes(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	title, err := NewTitle("Candlesticks and Volume Bars")
+	if err != nil {
+		panic(err)
+	}
+
+	price, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	price.X.Tick.Marker = plot.TimeTicks{Format: "15:04"}
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+	closes := make(plotter.XYs, len(testTOHLCVs))
+	for i, b := range testTOHLCVs {
+		closes[i].X, closes[i].Y = b.T, b.C
+	}
+	line, err := plotter.NewLine(closes)
+	if err != nil {
+		panic(err)
+	}
+	line.Color = plotter.DefaultGlyphStyle.Color
+	price.Add(sticks, line)
+
+	volume, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	volume.X.Tick.Marker = plot.TimeTicks{Format: "15:04"}
+	vbars, err := custplotter.NewVBars(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+	volume.Add(vbars)
+
+	legend, err := NewLegend()
+	if err != nil {
+		panic(err)
+	}
+	legend.Add("close", line)
+
+	// the title and the legend fit their texts, the volume
+	// panel is one inch high and the price panel gets the rest
+	table := Table{
+		RowSizes: []Size{Auto(), Rel(1), Abs(vg.Inch), Auto()},
+		ColSizes: []Size{Rel(1)},
+		PadTop:   2,
+		PadLeft:  2,
+		PadRight: 2,
+	}
+	cells := []Cell{
+		{Row: 0, Col: 0, Element: title},
+		{Row: 1, Col: 0, Plot: price},
+		{Row: 2, Col: 0, Plot: volume},
+		{Row: 3, Col: 0, Element: legend},
+	}
+
+	img := vgimg.New(vg.Points(250), vg.Points(250))
+	dc := draw.New(img)
+
+	table.DrawCells(cells, dc)
+
+	canvases := table.AlignCells(cells, dc)
+	if h := canvases[2].Max.Y - canvases[2].Min.Y; math.Abs(float64(h-vg.Inch)) > 1e-6 {
+		t.Errorf("height of the volume panel = %v, want %v", h, vg.Inch)
+	}
+	if _, h := title.Size(); math.Abs(float64(canvases[0].Max.Y-canvases[0].Min.Y-h)) > 1e-6 {
+		t.Errorf("height of the title = %v, want %v", canvases[0].Max.Y-canvases[0].Min.Y, h)
+	}
+
+	testFile := "testdata/tablesizes.png"
+	w, err := os.Create(testFile)
+	if err != nil {
+		panic(err)
+	}
+
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err := png.WriteTo(w); err != nil {
+		panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
+
+func TestAlignSizes(t *testing.T) {
+	ch := newTestChart()
+	ch.Panes[1].Size = Abs(vg.Inch)
+
+	plots := make([]*plot.Plot, len(ch.Panes))
+	for j, pane := range ch.Panes {
+		plots[j] = pane.Plot
+	}
+
+	dc := draw.New(vgimg.New(vg.Points(250), vg.Points(250)))
+
+	// the volume pane is one inch high in a stacked layout
+	tab := ch.table()
+	if tab.RowSizes == nil {
+		t.Fatal("table of a chart with a sized pane has no RowSizes")
+	}
+	canvases := tab.AlignStacked(plots, dc)
+	if h := canvases[1].Max.Y - canvases[1].Min.Y; math.Abs(float64(h-vg.Inch)) > 1e-6 {
+		t.Errorf("height of the volume pane = %v, want %v", h, vg.Inch)
+	}
+
+	// Align honours the sizes as well
+	column := [][]*plot.Plot{{plots[0]}, {plots[1]}, {plots[2]}}
+	tab.ColSizes = []Size{Rel(1)}
+	aligned := tab.Align(column, dc)
+	for j, row := range aligned {
+		if row[0] != canvases[j] {
+			t.Errorf("Align canvas %d = %v, want %v", j, row[0], canvases[j])
+		}
+	}
+
+	if _, err := tab.TryAlign(column, dc); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := tab.TryAlign(column[:2], dc); err == nil {
+		t.Error("expected error for missing row")
+	}
+
+	if err := ch.Save(vg.Points(250), vg.Points(200), "testdata/chartsizes.png"); err != nil {
+		panic(err)
+	}
+	internal.TestImage(t, "testdata/chartsizes.png")
+}
@@ -0,0 +1,110 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// StackX prepares plots stacked from top to bottom, e.g. candlesticks,
+// volume bars and an oscillator, to share the X axis of the bottom plot:
+//
+// The ranges of the X axes are united and the scale and the tick marker
+// of the bottom plot are used for all plots. The tick labels and the axis
+// labels of the X axes of all but the bottom plot are hidden, so that
+// Align and AlignStacked reclaim their space. The tick marks remain, but
+// as the ticks have no labels they are drawn like minor ticks.
+// Use AddXGrid for vertical gridlines at the ticks of the shared X axis.
+//
+// Plots that are nil are skipped.
+func StackX(plots []*plot.Plot) {
+	var axes []*plot.Axis
+	var bottom *plot.Plot
+	for _, p := range plots {
+		if p != nil {
+			axes = append(axes, &p.X)
+			bottom = p
+		}
+	}
+	if bottom == nil {
+		return
+	}
+
+	UniteAxisRanges(axes)
+
+	for _, p := range plots {
+		if p == nil {
+			continue
+		}
+		if p != bottom {
+			p.X.Scale = bottom.X.Scale
+			p.X.Tick.Marker = unlabeledTicks{bottom.X.Tick.Marker}
+			p.X.Label.Text = ""
+		}
+	}
+}
+
+// unlabeledTicks is a plot.Ticker returning
+// the ticks of Ticker without their labels.
+type unlabeledTicks struct {
+	plot.Ticker
+}
+
+// Ticks implements the Ticks method of the plot.Ticker interface.
+func (t unlabeledTicks) Ticks(min, max float64) []plot.Tick {
+	ticks := append([]plot.Tick(nil), t.Ticker.Ticks(min, max)...)
+	for i := range ticks {
+		ticks[i].Label = ""
+	}
+	return ticks
+}
+
+// xGrid is a plotter.Grid drawing the gridlines at the major ticks of the
+// X axis even if their labels are hidden by StackX.
+type xGrid struct {
+	*plotter.Grid
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (g xGrid) Plot(c draw.Canvas, plt *plot.Plot) {
+	if t, ok := plt.X.Tick.Marker.(unlabeledTicks); ok {
+		cpy := *plt
+		cpy.X.Tick.Marker = t.Ticker
+		plt = &cpy
+	}
+	g.Grid.Plot(c, plt)
+}
+
+// AddXGrid adds vertical gridlines at the ticks of the X axis to p.
+// Plotters are drawn in the order they are added, so AddXGrid must be
+// called before the other plotters are added to draw the gridlines
+// beneath them.
+func AddXGrid(p *plot.Plot) {
+	g := plotter.NewGrid()
+	g.Horizontal.Color = nil
+	p.Add(xGrid{g})
+}
+
+// AlignStacked returns the Canvases of plots stacked from top to bottom
+// in a single column using the RowHeights or, if set, the RowSizes of the
+// table (see Align). ColWidths and ColSizes are ignored. Call StackX first
+// to share the X axis.
+func (tab Table) AlignStacked(plots []*plot.Plot, dc draw.Canvas) []draw.Canvas {
+	column := make([][]*plot.Plot, len(plots))
+	for j, p := range plots {
+		column[j] = []*plot.Plot{p}
+	}
+	tab.ColWidths = []float64{1}
+	tab.ColSizes = nil
+
+	canvases := tab.Align(column, dc)
+	o := make([]draw.Canvas, len(canvases))
+	for j, row := range canvases {
+		o[j] = row[0]
+	}
+	return o
+}
@@ -0,0 +1,111 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"os"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/internal"
+)
+
+func TestAlignStacked(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	price, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+	// the gridlines are added first to be drawn beneath the data
+	AddXGrid(price)
+	price.Add(sticks)
+
+	volume, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	vbars, err := custplotter.NewVBars(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+	AddXGrid(volume)
+	volume.Add(vbars)
+
+	oscillator, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	oscillator.X.Tick.Marker = plot.TimeTicks{Format: "15:04"}
+	oscillator.X.Label.Text = "Time"
+	momentum := make(plotter.XYs, len(testTOHLCVs)-1)
+	for i := range momentum {
+		momentum[i].X = testTOHLCVs[i+1].T
+		momentum[i].Y = testTOHLCVs[i+1].C - testTOHLCVs[i].C
+	}
+	line, err := plotter.NewLine(momentum)
+	if err != nil {
+		panic(err)
+	}
+	AddXGrid(oscillator)
+	oscillator.Add(line)
+
+	plots := []*plot.Plot{price, volume, oscillator}
+	StackX(plots)
+
+	for _, p := range plots[:2] {
+		for _, tk := range p.X.Tick.Marker.Ticks(p.X.Min, p.X.Max) {
+			if tk.Label != "" {
+				t.Errorf("tick label %q of an inner X axis is not hidden", tk.Label)
+			}
+		}
+		if p.X.Tick.Label.Font.Size == 0 {
+			t.Error("font of the tick labels of an inner X axis is changed")
+		}
+		if p.X.Min != oscillator.X.Min || p.X.Max != oscillator.X.Max {
+			t.Errorf("X range [%v, %v] differs from the bottom plot [%v, %v]", p.X.Min, p.X.Max, oscillator.X.Min, oscillator.X.Max)
+		}
+	}
+
+	table := Table{
+		RowHeights: []float64{3, 1, 1},
+		PadTop:     2,
+		PadBottom:  2,
+		PadLeft:    2,
+		PadRight:   2,
+		PadY:       2,
+	}
+
+	img := vgimg.New(vg.Points(250), vg.Points(250))
+	dc := draw.New(img)
+
+	canvases := table.AlignStacked(plots, dc)
+	for j, p := range plots {
+		p.Draw(canvases[j])
+	}
+
+	testFile := "testdata/alignstacked.png"
+	w, err := os.Create(testFile)
+	if err != nil {
+		panic(err)
+	}
+
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err := png.WriteTo(w); err != nil {
+		panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
@@ -10,6 +10,7 @@ package plotext
 import (
 	"fmt"
 	"math"
+	"reflect"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
@@ -32,11 +33,24 @@ type Table struct {
 	// PadX and PadY specify the padding between columns and rows
 	// of tiles respectively..
 	PadX, PadY vg.Length
+	// RowSizes and ColSizes specify the number of rows and columns and
+	// their sizes, which may mix relative, absolute and Auto sizes. If set,
+	// they are used instead of RowHeights and ColWidths respectively by
+	// Align, AlignStacked, AlignCells and DrawCells. At and AtSpan only
+	// use RowHeights and ColWidths.
+	RowSizes, ColSizes []Size
 }
 
 // At returns the subcanvas within c that corresponds to the
 // cell at column x, row y, where 0, 0 is the upper, right corner
+// At panics if the cell is outside of the RowHeights and ColWidths,
+// see TryAt for a variant returning an error instead.
 func (tab Table) At(c draw.Canvas, x, y int) draw.Canvas {
+	if y < 0 || y >= len(tab.RowHeights) || x < 0 || x >= len(tab.ColWidths) {
+		panic(fmt.Errorf("plotext: cell at row %d, column %d is outside of the table (%d RowHeights, %d ColWidths); At does not use RowSizes and ColSizes",
+			y, x, len(tab.RowHeights), len(tab.ColWidths)))
+	}
+
 	// Canvas origin is left, bottom. Positive directions are right, up
 	var sumColWidths float64
 	for _, relColWidth := range tab.ColWidths {
@@ -88,17 +102,24 @@ func (tab Table) At(c draw.Canvas, x, y int) draw.Canvas {
 // produce plots with DataCanvases that are neatly aligned.
 // The arguments to the function are a two-dimensional row-major array
 // of plots and the canvas to which the plots are to be drawn.
+// If RowSizes or ColSizes are set, the plots are aligned by AlignCells.
+// Align panics if the dimensions of plots do not match the table,
+// see TryAlign for a variant returning an error instead.
 func (tab Table) Align(plots [][]*plot.Plot, dc draw.Canvas) [][]draw.Canvas {
+	if tab.RowSizes != nil || tab.ColSizes != nil {
+		return tab.alignSizes(plots, dc)
+	}
+
 	o := make([][]draw.Canvas, len(plots))
 
 	if len(plots) != len(tab.RowHeights) {
-		panic(fmt.Errorf("plot: plots rows (%d) != tiles rows (%d)", len(plots), tab.RowHeights))
+		panic(fmt.Errorf("plot: plots rows (%d) != tiles rows (%d)", len(plots), len(tab.RowHeights)))
 	}
 
 	// Create the initial tiles.
 	for j := 0; j < len(tab.RowHeights); j++ {
 		if len(plots[j]) != len(tab.ColWidths) {
-			panic(fmt.Errorf("plot: plots row %d columns (%d) != tiles columns (%d)", j, len(plots[j]), tab.RowHeights))
+			panic(fmt.Errorf("plot: plots row %d columns (%d) != tiles columns (%d)", j, len(plots[j]), len(tab.ColWidths)))
 		}
 
 		o[j] = make([]draw.Canvas, len(plots[j]))
@@ -199,3 +220,312 @@ func (tab Table) Align(plots [][]*plot.Plot, dc draw.Canvas) [][]draw.Canvas {
 
 	return o
 }
+
+// alignSizes is Align for tables with RowSizes or ColSizes. The plots
+// are placed in cells of one row and one column and aligned by AlignCells.
+func (tab Table) alignSizes(plots [][]*plot.Plot, dc draw.Canvas) [][]draw.Canvas {
+	rows, cols := len(tab.rowSizes()), len(tab.colSizes())
+	if len(plots) != rows {
+		panic(fmt.Errorf("plot: plots rows (%d) != tiles rows (%d)", len(plots), rows))
+	}
+
+	var cells []Cell
+	for j, row := range plots {
+		if len(row) != cols {
+			panic(fmt.Errorf("plot: plots row %d columns (%d) != tiles columns (%d)", j, len(row), cols))
+		}
+		for i, p := range row {
+			cells = append(cells, Cell{Row: j, Col: i, Plot: p})
+		}
+	}
+
+	canvases := tab.AlignCells(cells, dc)
+	o := make([][]draw.Canvas, len(plots))
+	for j := range o {
+		o[j] = canvases[j*cols : (j+1)*cols]
+	}
+	return o
+}
+
+// Cell places a plot or a nested table in a Table. The cell spans RowSpan
+// rows starting at row Row and ColSpan columns starting at column Col.
+// Spans less than 1 are treated as 1.
+type Cell struct {
+	Row, Col         int
+	RowSpan, ColSpan int
+
+	// Plot is the plot of the cell.
+	Plot *plot.Plot
+
+	// Nested is the table of the cell. It is ignored if Plot is set.
+	Nested *NestedTable
+
+	// Element is drawn into the data area of the cell.
+	// It is ignored if Plot or Nested is set.
+	Element Element
+}
+
+// dataCanvaser wraps the DataCanvas method
+// of plot.Plot and NestedTable.
+type dataCanvaser interface {
+	DataCanvas(da draw.Canvas) draw.Canvas
+}
+
+// content returns the plot or the nested table of the cell
+// or nil if the cell is empty.
+func (cell Cell) content() dataCanvaser {
+	switch {
+	case cell.Plot != nil:
+		return cell.Plot
+	case cell.Nested != nil:
+		return cell.Nested
+	}
+	return nil
+}
+
+// spans returns the row and column spans of the cell.
+func (cell Cell) spans() (rowSpan, colSpan int) {
+	rowSpan, colSpan = cell.RowSpan, cell.ColSpan
+	if rowSpan < 1 {
+		rowSpan = 1
+	}
+	if colSpan < 1 {
+		colSpan = 1
+	}
+	return rowSpan, colSpan
+}
+
+// AtSpan returns the subcanvas within c that corresponds to the cells
+// starting at column x, row y and spanning w columns and h rows
+// including the padding between them. Like At, it only uses
+// RowHeights and ColWidths.
+func (tab Table) AtSpan(c draw.Canvas, x, y, w, h int) draw.Canvas {
+	first := tab.At(c, x, y)
+	last := tab.At(c, x+w-1, y+h-1)
+	return draw.Canvas{
+		Canvas: vg.Canvas(c),
+		Rectangle: vg.Rectangle{
+			Min: vg.Point{X: first.Min.X, Y: last.Min.Y},
+			Max: vg.Point{X: last.Max.X, Y: first.Max.Y},
+		},
+	}
+}
+
+// AlignCells is like Align, but the plots are placed by cells which may
+// span several rows and columns. It returns a Canvas for each cell.
+// The DataCanvases are aligned along the edges of the columns and rows,
+// i.e. the DataCanvas of a plot spanning several columns starts where the
+// DataCanvases in its first column start and ends where the DataCanvases
+// in its last column end. For empty cells the Canvas of the data area of
+// the cell is returned. Nested tables are aligned like plots, see
+// NestedTable.DataCanvas.
+func (tab Table) AlignCells(cells []Cell, dc draw.Canvas) []draw.Canvas {
+	rows, cols := tab.rowSizes(), tab.colSizes()
+	for _, cell := range cells {
+		rowSpan, colSpan := cell.spans()
+		if cell.Row < 0 || cell.Row+rowSpan > len(rows) || cell.Col < 0 || cell.Col+colSpan > len(cols) {
+			panic(fmt.Errorf("plotext: cell at row %d, column %d spanning %d rows and %d columns is outside of the table (%d rows, %d columns)",
+				cell.Row, cell.Col, rowSpan, colSpan, len(rows), len(cols)))
+		}
+	}
+
+	// The natural widths and heights of the elements
+	// are used for Auto columns and rows.
+	naturalX := make([]float64, len(cols))
+	naturalY := make([]float64, len(rows))
+	for _, cell := range cells {
+		rowSpan, colSpan := cell.spans()
+		if cell.Plot != nil || cell.Nested != nil || cell.Element == nil {
+			continue
+		}
+		w, h := cell.Element.Size()
+		if colSpan == 1 {
+			naturalX[cell.Col] = math.Max(float64(w), naturalX[cell.Col])
+		}
+		if rowSpan == 1 {
+			naturalY[cell.Row] = math.Max(float64(h), naturalY[cell.Row])
+		}
+	}
+
+	type posNeg struct {
+		p, n float64 // x: n = left, p = right; y: n = bottom; p = top
+	}
+	xSpacing := make([]posNeg, len(cols))
+	ySpacing := make([]posNeg, len(rows))
+
+	// edges returns the minimum and maximum of the data areas of the
+	// columns and rows for the given spacing around the data areas.
+	edges := func() (dataMinX, dataMaxX, dataMinY, dataMaxY []vg.Length) {
+		availableX := float64(dc.Max.X-dc.Min.X-tab.PadLeft-tab.PadRight) - float64(len(cols)-1)*float64(tab.PadX)
+		spaceX := make([]float64, len(cols))
+		for i, s := range xSpacing {
+			spaceX[i] = s.n + s.p
+		}
+		widths := lengths(cols, naturalX, spaceX, availableX)
+
+		availableY := float64(dc.Max.Y-dc.Min.Y-tab.PadTop-tab.PadBottom) - float64(len(rows)-1)*float64(tab.PadY)
+		spaceY := make([]float64, len(rows))
+		for j, s := range ySpacing {
+			spaceY[j] = s.n + s.p
+		}
+		heights := lengths(rows, naturalY, spaceY, availableY)
+
+		dataMinX = make([]vg.Length, len(cols))
+		dataMaxX = make([]vg.Length, len(cols))
+		x := dc.Min.X + tab.PadLeft
+		for i := range cols {
+			dataMinX[i] = x + vg.Length(xSpacing[i].n)
+			dataMaxX[i] = dataMinX[i] + vg.Length(widths[i])
+			x = dataMaxX[i] + vg.Length(xSpacing[i].p) + tab.PadX
+		}
+		dataMinY = make([]vg.Length, len(rows))
+		dataMaxY = make([]vg.Length, len(rows))
+		y := dc.Max.Y - tab.PadTop
+		for j := range rows {
+			dataMaxY[j] = y - vg.Length(ySpacing[j].p)
+			dataMinY[j] = dataMaxY[j] - vg.Length(heights[j])
+			y = dataMinY[j] - vg.Length(ySpacing[j].n) - tab.PadY
+		}
+		return dataMinX, dataMaxX, dataMinY, dataMaxY
+	}
+
+	// span returns the rectangle from the first to the last column and row of the cell.
+	span := func(cell Cell, minX, maxX, minY, maxY []vg.Length) vg.Rectangle {
+		rowSpan, colSpan := cell.spans()
+		return vg.Rectangle{
+			Min: vg.Point{X: minX[cell.Col], Y: minY[cell.Row+rowSpan-1]},
+			Max: vg.Point{X: maxX[cell.Col+colSpan-1], Y: maxY[cell.Row]},
+		}
+	}
+
+	// Calculate the maximum spacing between data canvases
+	// for each row and column. The left and top spacing of a
+	// cell counts for its first column and row, the right and
+	// bottom spacing for its last column and row.
+	//
+	// The spacing is measured with canvases placed by the spacing of the
+	// previous pass, starting without spacing. A canvas too small for the
+	// axes of its plot overestimates the spacing, e.g. the one of a small
+	// relative row next to a large absolute row, so the spacing is measured
+	// again until it does not change anymore.
+	initial := make([]draw.Canvas, len(cells))
+	minX, maxX, minY, maxY := edges()
+	for pass := 0; pass < 20; pass++ {
+		xs := make([]posNeg, len(cols))
+		ys := make([]posNeg, len(rows))
+		for k, cell := range cells {
+			rowSpan, colSpan := cell.spans()
+			c := draw.Canvas{Canvas: vg.Canvas(dc), Rectangle: span(cell, minX, maxX, minY, maxY)}
+			c.Min.X -= vg.Length(xSpacing[cell.Col].n)
+			c.Max.X += vg.Length(xSpacing[cell.Col+colSpan-1].p)
+			c.Min.Y -= vg.Length(ySpacing[cell.Row+rowSpan-1].n)
+			c.Max.Y += vg.Length(ySpacing[cell.Row].p)
+			initial[k] = c
+			content := cell.content()
+			if content == nil {
+				continue
+			}
+			dataC := content.DataCanvas(c)
+			first, last := cell.Col, cell.Col+colSpan-1
+			top, bottom := cell.Row, cell.Row+rowSpan-1
+			xs[first].n = math.Max(float64(dataC.Min.X-c.Min.X), xs[first].n)
+			xs[last].p = math.Max(float64(c.Max.X-dataC.Max.X), xs[last].p)
+			ys[bottom].n = math.Max(float64(dataC.Min.Y-c.Min.Y), ys[bottom].n)
+			ys[top].p = math.Max(float64(c.Max.Y-dataC.Max.Y), ys[top].p)
+		}
+		if reflect.DeepEqual(xs, xSpacing) && reflect.DeepEqual(ys, ySpacing) {
+			break
+		}
+		xSpacing, ySpacing = xs, ys
+		minX, maxX, minY, maxY = edges()
+	}
+
+	o := make([]draw.Canvas, len(cells))
+	for k, cell := range cells {
+		data := span(cell, minX, maxX, minY, maxY)
+
+		c := initial[k]
+		c.Rectangle = data
+		if content := cell.content(); content != nil {
+			// The spacing around the DataCanvas depends slightly on the
+			// size of the canvas, so the canvas is adjusted until it
+			// does not change anymore.
+			c = initial[k]
+			for pass := 0; pass < 20; pass++ {
+				dataC := content.DataCanvas(c)
+				r := vg.Rectangle{
+					Min: vg.Point{X: data.Min.X - (dataC.Min.X - c.Min.X), Y: data.Min.Y - (dataC.Min.Y - c.Min.Y)},
+					Max: vg.Point{X: data.Max.X + (c.Max.X - dataC.Max.X), Y: data.Max.Y + (c.Max.Y - dataC.Max.Y)},
+				}
+				if r == c.Rectangle {
+					break
+				}
+				c.Rectangle = r
+			}
+		}
+		o[k] = c
+	}
+
+	return o
+}
+
+// NestedTable is a table placed in a cell of another table.
+type NestedTable struct {
+	Table
+
+	// Cells are the cells of the nested table.
+	Cells []Cell
+
+	// AlignOuter determines if the outer edges of the DataCanvases of the
+	// nested table are aligned with the DataCanvases of the other cells of
+	// the outer table. Otherwise the nested table fills the data area of its
+	// cell and only the DataCanvases of the nested table are aligned.
+	AlignOuter bool
+}
+
+// AlignNested returns a Canvas for each cell of the
+// nested table aligned within c (see AlignCells).
+func (nt *NestedTable) AlignNested(c draw.Canvas) []draw.Canvas {
+	return nt.AlignCells(nt.Cells, c)
+}
+
+// DataCanvas returns the smallest Canvas containing the DataCanvases of
+// all cells of the nested table aligned within da if AlignOuter is set.
+// Otherwise it returns da.
+func (nt *NestedTable) DataCanvas(da draw.Canvas) draw.Canvas {
+	if !nt.AlignOuter || len(nt.Cells) == 0 {
+		return da
+	}
+
+	hull := vg.Rectangle{
+		Min: vg.Point{X: vg.Length(math.Inf(1)), Y: vg.Length(math.Inf(1))},
+		Max: vg.Point{X: vg.Length(math.Inf(-1)), Y: vg.Length(math.Inf(-1))},
+	}
+	for k, c := range nt.AlignNested(da) {
+		if content := nt.Cells[k].content(); content != nil {
+			c = content.DataCanvas(c)
+		}
+		hull.Min.X = vg.Length(math.Min(float64(hull.Min.X), float64(c.Min.X)))
+		hull.Min.Y = vg.Length(math.Min(float64(hull.Min.Y), float64(c.Min.Y)))
+		hull.Max.X = vg.Length(math.Max(float64(hull.Max.X), float64(c.Max.X)))
+		hull.Max.Y = vg.Length(math.Max(float64(hull.Max.Y), float64(c.Max.Y)))
+	}
+	da.Rectangle = hull
+	return da
+}
+
+// DrawCells aligns the cells within dc (see AlignCells) and draws their
+// plots and elements. The cells of nested tables are aligned and drawn
+// recursively.
+func (tab Table) DrawCells(cells []Cell, dc draw.Canvas) {
+	for k, c := range tab.AlignCells(cells, dc) {
+		switch cell := cells[k]; {
+		case cell.Plot != nil:
+			cell.Plot.Draw(c)
+		case cell.Nested != nil:
+			cell.Nested.DrawCells(cell.Nested.Cells, c)
+		case cell.Element != nil:
+			cell.Element.Draw(c)
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"gonum.org/v1/plot/vg/draw"
 	"gonum.org/v1/plot/vg/vgimg"
 
+	"github.com/pplcc/plotext/custplotter"
 	"github.com/pplcc/plotext/internal"
 )
 
@@ -165,3 +166,189 @@ func TestAlign(t *testing.T) {
 
 	internal.TestImage(t, testFile)
 }
+
+func TestAlignCells(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	// +-------------+
+	// |    price    | 2
+	// +------+------+
+	// |volume| line | 1
+	// +------+------+
+	//     1      1
+
+	table := Table{
+		ColWidths:  []float64{1, 1},
+		RowHeights: []float64{2, 1},
+		PadTop:     2,
+		PadBottom:  2,
+		PadRight:   2,
+		PadLeft:    2,
+		PadX:       4,
+		PadY:       4,
+	}
+
+	price, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	price.X.Tick.Marker = plot.TimeTicks{Format: "15:04"}
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+	price.Add(sticks)
+
+	volume, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	volume.X.Tick.Marker = plot.TimeTicks{Format: "15:04"}
+	vbars, err := custplotter.NewVBars(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+	volume.Add(vbars)
+
+	// wide tick labels on the right to test the alignment
+	other, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	other.Y.Max = 1e9
+	other.X.Max = 1e9
+	other.Y.Padding = 10
+
+	cells := []Cell{
+		{Row: 0, Col: 0, ColSpan: 2, Plot: price},
+		{Row: 1, Col: 0, Plot: volume},
+		{Row: 1, Col: 1, Plot: other},
+	}
+
+	img := vgimg.New(vg.Points(300), vg.Points(200))
+	dc := draw.New(img)
+
+	canvases := table.AlignCells(cells, dc)
+	for k, cell := range cells {
+		cell.Plot.Draw(canvases[k])
+	}
+
+	const tol = 1e-6
+	wide := price.DataCanvas(canvases[0])
+	left := volume.DataCanvas(canvases[1])
+	right := other.DataCanvas(canvases[2])
+	if math.Abs(float64(wide.Min.X-left.Min.X)) > tol || math.Abs(float64(wide.Max.X-right.Max.X)) > tol {
+		t.Errorf("spanned data canvas %v is not aligned with %v and %v", wide.Rectangle, left.Rectangle, right.Rectangle)
+	}
+	if math.Abs(float64(left.Max.Y-right.Max.Y)) > tol || math.Abs(float64(left.Min.Y-right.Min.Y)) > tol {
+		t.Errorf("data canvases %v and %v of a row are not aligned", left.Rectangle, right.Rectangle)
+	}
+
+	testFile := "testdata/tablealigncells.png"
+	w, err := os.Create(testFile)
+	if err != nil {
+		panic(err)
+	}
+
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err := png.WriteTo(w); err != nil {
+		panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
+
+func TestNestedTable(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	// two symbols side by side, each a price and volume stack
+	stack := func(volumeScale float64) *NestedTable {
+		price, err := plot.New()
+		if err != nil {
+			panic(err)
+		}
+		price.X.Tick.Marker = plot.TimeTicks{Format: "15:04"}
+		sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+		if err != nil {
+			panic(err)
+		}
+		price.Add(sticks)
+
+		data := make(custplotter.TOHLCVs, len(testTOHLCVs))
+		copy(data, testTOHLCVs)
+		for i := range data {
+			data[i].V *= volumeScale
+		}
+		volume, err := plot.New()
+		if err != nil {
+			panic(err)
+		}
+		volume.X.Tick.Marker = plot.TimeTicks{Format: "15:04"}
+		vbars, err := custplotter.NewVBars(data)
+		if err != nil {
+			panic(err)
+		}
+		volume.Add(vbars)
+
+		return &NestedTable{
+			Table: Table{
+				RowHeights: []float64{2, 1},
+				ColWidths:  []float64{1},
+				PadY:       2,
+			},
+			Cells: []Cell{
+				{Row: 0, Col: 0, Plot: price},
+				{Row: 1, Col: 0, Plot: volume},
+			},
+			AlignOuter: true,
+		}
+	}
+
+	table := Table{
+		RowHeights: []float64{1},
+		ColWidths:  []float64{1, 1},
+		PadTop:     2,
+		PadBottom:  2,
+		PadRight:   2,
+		PadLeft:    2,
+		PadX:       8,
+	}
+	left, right := stack(1), stack(1e6)
+	cells := []Cell{
+		{Row: 0, Col: 0, Nested: left},
+		{Row: 0, Col: 1, Nested: right},
+	}
+
+	img := vgimg.New(vg.Points(300), vg.Points(200))
+	dc := draw.New(img)
+
+	table.DrawCells(cells, dc)
+
+	const tol = 1e-6
+	canvases := table.AlignCells(cells, dc)
+	for k, nested := range []*NestedTable{left, right} {
+		inner := nested.AlignNested(canvases[k])
+		price := nested.Cells[0].Plot.DataCanvas(inner[0])
+		volume := nested.Cells[1].Plot.DataCanvas(inner[1])
+		if math.Abs(float64(price.Min.X-volume.Min.X)) > tol || math.Abs(float64(price.Max.X-volume.Max.X)) > tol {
+			t.Errorf("nested data canvases %v and %v are not aligned", price.Rectangle, volume.Rectangle)
+		}
+	}
+	lh, rh := left.DataCanvas(canvases[0]), right.DataCanvas(canvases[1])
+	if math.Abs(float64(lh.Min.Y-rh.Min.Y)) > tol || math.Abs(float64(lh.Max.Y-rh.Max.Y)) > tol {
+		t.Errorf("nested tables %v and %v are not aligned", lh.Rectangle, rh.Rectangle)
+	}
+
+	testFile := "testdata/tablenested.png"
+	w, err := os.Create(testFile)
+	if err != nil {
+		panic(err)
+	}
+
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err := png.WriteTo(w); err != nil {
+		panic(err)
+	}
+
+	internal.TestImage(t, testFile)
+}
@@ -5,19 +5,22 @@
 package plotext
 
 import (
+	"fmt"
 	"math"
 
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
 )
 
 // UniteAxisRanges sets the range of all axises to the minimum and the maximum of all axises.
+// Bounds which are NaN are ignored.
 func UniteAxisRanges(axises []*plot.Axis) {
 	min := math.MaxFloat64
 	max := -math.MaxFloat64
 
 	for _, axis := range axises {
-		min = math.Min(axis.Min, min)
-		max = math.Max(axis.Max, max)
+		min = minNotNaN(axis.Min, min)
+		max = maxNotNaN(axis.Max, max)
 	}
 
 	for _, axis := range axises {
@@ -27,3 +30,203 @@ func UniteAxisRanges(axises []*plot.Axis) {
 
 	return
 }
+
+// WindowDataRanger wraps the WindowDataRange method. It is implemented
+// by plotters, e.g. the bar plotters of custplotter, which can compute
+// the Y range of their data within a window of the X axis.
+type WindowDataRanger interface {
+	// WindowDataRange returns the range of the Y values
+	// of the data with X in [xmin, xmax].
+	WindowDataRange(xmin, xmax float64) (ymin, ymax float64)
+}
+
+// UniteOptions are the options of UniteRanges.
+type UniteOptions struct {
+	// X and Y determine if the X axes and the Y axes are united.
+	X, Y bool
+
+	// Window determines if the X axes are set to [XMin, XMax] and if
+	// only the data within this window is used for the Y ranges.
+	Window     bool
+	XMin, XMax float64
+
+	// Margin is added to both ends of the united ranges relative
+	// to their lengths, e.g. 0.05 for 5 percent.
+	Margin float64
+
+	// Nice determines if the united ranges are extended to the next
+	// major ticks of the tick marker of the first axis. The major ticks
+	// are assumed to be evenly spaced, e.g. the ones of plot.DefaultTicks.
+	Nice bool
+}
+
+// UniteRanges unites the ranges of the axes of the plots according to
+// opts. Plots that are nil are skipped.
+//
+// If plotters is nil, the current ranges of the axes are united.
+// Otherwise plotters[i] are the plotters of plots[i] and the ranges are
+// computed from the DataRange of the plotters, so that e.g. the Y ranges
+// fit the bars within a window (see WindowDataRanger). An error is returned
+// if plotters is not nil and its length differs from the length of plots.
+// Row and Column select the plots of a row or a column of Table cells.
+func UniteRanges(plots []*plot.Plot, plotters [][]plot.Plotter, opts UniteOptions) error {
+	if plotters != nil && len(plotters) != len(plots) {
+		return fmt.Errorf("plotext: %d plotter lists for %d plots", len(plotters), len(plots))
+	}
+
+	var xs, ys []*plot.Axis
+	xmin, xmax := math.Inf(1), math.Inf(-1)
+	ymin, ymax := math.Inf(1), math.Inf(-1)
+	for i, p := range plots {
+		if p == nil {
+			continue
+		}
+		xs = append(xs, &p.X)
+		ys = append(ys, &p.Y)
+
+		pxmin, pxmax, pymin, pymax := p.X.Min, p.X.Max, p.Y.Min, p.Y.Max
+		if plotters != nil {
+			pxmin, pxmax, pymin, pymax = dataRange(plotters[i], opts)
+		}
+		xmin, xmax = minNotNaN(xmin, pxmin), maxNotNaN(xmax, pxmax)
+		ymin, ymax = minNotNaN(ymin, pymin), maxNotNaN(ymax, pymax)
+	}
+	if len(xs) == 0 {
+		return nil
+	}
+
+	if opts.X {
+		if opts.Window {
+			xmin, xmax = opts.XMin, opts.XMax
+		}
+		setRange(xs, xmin, xmax, opts)
+	}
+	if opts.Y {
+		setRange(ys, ymin, ymax, opts)
+	}
+	return nil
+}
+
+// Row returns the plots of row j of the plots of a table.
+func Row(plots [][]*plot.Plot, j int) []*plot.Plot {
+	return append([]*plot.Plot(nil), plots[j]...)
+}
+
+// Column returns the plots of column i of the plots of a table.
+// Rows with less than i+1 plots are skipped.
+func Column(plots [][]*plot.Plot, i int) []*plot.Plot {
+	var o []*plot.Plot
+	for _, row := range plots {
+		if i < len(row) {
+			o = append(o, row[i])
+		}
+	}
+	return o
+}
+
+// setRange sets the range of the axes to [min, max]
+// extended by the margin and to nice values.
+func setRange(axes []*plot.Axis, min, max float64, opts UniteOptions) {
+	if math.IsInf(min, 0) || math.IsInf(max, 0) {
+		// no data
+		return
+	}
+
+	if opts.Margin != 0 {
+		m := (max - min) * opts.Margin
+		min, max = min-m, max+m
+	}
+	if opts.Nice {
+		min, max = nice(axes[0].Tick.Marker, min, max)
+	}
+
+	for _, a := range axes {
+		a.Min = min
+		a.Max = max
+	}
+}
+
+// nice extends [min, max] to the next major ticks of m.
+func nice(m plot.Ticker, min, max float64) (float64, float64) {
+	var majors []float64
+	for _, t := range m.Ticks(min, max) {
+		if !t.IsMinor() {
+			majors = append(majors, t.Value)
+		}
+	}
+	if len(majors) < 2 {
+		return min, max
+	}
+
+	step := majors[1] - majors[0]
+	if step <= 0 {
+		return min, max
+	}
+	first, last := majors[0], majors[len(majors)-1]
+	return first - math.Ceil((first-min)/step)*step, last + math.Ceil((max-last)/step)*step
+}
+
+// dataRange returns the union of the data ranges of the plotters.
+// If opts.Window is set, the Y range only covers the data within the window.
+func dataRange(ps []plot.Plotter, opts UniteOptions) (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, d := range ps {
+		dr, ok := d.(plot.DataRanger)
+		if !ok {
+			continue
+		}
+		dxmin, dxmax, dymin, dymax := dr.DataRange()
+		if opts.Window {
+			if dxmax < opts.XMin || dxmin > opts.XMax {
+				continue
+			}
+			dymin, dymax = windowYRange(d, opts.XMin, opts.XMax, dymin, dymax)
+		}
+		xmin, xmax = minNotNaN(xmin, dxmin), maxNotNaN(xmax, dxmax)
+		ymin, ymax = minNotNaN(ymin, dymin), maxNotNaN(ymax, dymax)
+	}
+	return
+}
+
+// windowYRange returns the Y range of the data of d with X in [xmin, xmax]
+// for WindowDataRangers and plotter.XYers. For other plotters the Y range
+// ymin, ymax of their DataRange is returned.
+func windowYRange(d plot.Plotter, xmin, xmax, ymin, ymax float64) (float64, float64) {
+	switch d := d.(type) {
+	case WindowDataRanger:
+		return d.WindowDataRange(xmin, xmax)
+	case plotter.XYer:
+		ymin, ymax = math.Inf(1), math.Inf(-1)
+		for i := 0; i < d.Len(); i++ {
+			x, y := d.XY(i)
+			if x < xmin || x > xmax {
+				continue
+			}
+			ymin, ymax = minNotNaN(ymin, y), maxNotNaN(ymax, y)
+		}
+	}
+	return ymin, ymax
+}
+
+// minNotNaN returns the minimum of a and b ignoring NaN.
+func minNotNaN(a, b float64) float64 {
+	switch {
+	case math.IsNaN(a):
+		return b
+	case math.IsNaN(b):
+		return a
+	}
+	return math.Min(a, b)
+}
+
+// maxNotNaN returns the maximum of a and b ignoring NaN.
+func maxNotNaN(a, b float64) float64 {
+	switch {
+	case math.IsNaN(a):
+		return b
+	case math.IsNaN(b):
+		return a
+	}
+	return math.Max(a, b)
+}
@@ -0,0 +1,162 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+
+	"github.com/pplcc/plotext/custplotter"
+	"github.com/pplcc/plotext/internal"
+)
+
+func TestUniteAxisRangesNaN(t *testing.T) {
+	a1 := &plot.Axis{Min: math.NaN(), Max: math.NaN()}
+	a2 := &plot.Axis{Min: 1, Max: 3}
+	a3 := &plot.Axis{Min: 2, Max: 5}
+
+	UniteAxisRanges([]*plot.Axis{a1, a2, a3})
+
+	for _, a := range []*plot.Axis{a1, a2, a3} {
+		if a.Min != 1 || a.Max != 5 {
+			t.Errorf("unexpected range [%v, %v], want [1, 5]", a.Min, a.Max)
+		}
+	}
+}
+
+func TestUniteRanges(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	price, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	sticks, err := custplotter.NewCandlesticks(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+	price.Add(sticks)
+
+	volume, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	vbars, err := custplotter.NewVBars(testTOHLCVs)
+	if err != nil {
+		panic(err)
+	}
+	volume.Add(vbars)
+
+	plots := [][]*plot.Plot{{price}, {volume}}
+	plotters := [][]plot.Plotter{{sticks}}
+
+	// window over the bars 5 to 9
+	xmin, xmax := testTOHLCVs[5].T, testTOHLCVs[9].T
+	ymin, ymax := math.Inf(1), math.Inf(-1)
+	for _, TOHLCV := range testTOHLCVs[5:10] {
+		ymin = math.Min(ymin, TOHLCV.L)
+		ymax = math.Max(ymax, TOHLCV.H)
+	}
+
+	if err := UniteRanges(Column(plots, 0)[:1], plotters, UniteOptions{
+		X: true, Y: true, Window: true, XMin: xmin, XMax: xmax,
+	}); err != nil {
+		panic(err)
+	}
+	if price.X.Min != xmin || price.X.Max != xmax {
+		t.Errorf("unexpected X range [%v, %v], want [%v, %v]", price.X.Min, price.X.Max, xmin, xmax)
+	}
+	if price.Y.Min != ymin || price.Y.Max != ymax {
+		t.Errorf("unexpected Y range [%v, %v], want [%v, %v]", price.Y.Min, price.Y.Max, ymin, ymax)
+	}
+
+	// margin of 10 percent
+	if err := UniteRanges([]*plot.Plot{price}, plotters, UniteOptions{
+		Y: true, Window: true, XMin: xmin, XMax: xmax, Margin: 0.1,
+	}); err != nil {
+		panic(err)
+	}
+	m := (ymax - ymin) * 0.1
+	if price.Y.Min != ymin-m || price.Y.Max != ymax+m {
+		t.Errorf("unexpected Y range [%v, %v], want [%v, %v]", price.Y.Min, price.Y.Max, ymin-m, ymax+m)
+	}
+
+	// nice values
+	if err := UniteRanges([]*plot.Plot{price}, plotters, UniteOptions{
+		Y: true, Window: true, XMin: xmin, XMax: xmax, Nice: true,
+	}); err != nil {
+		panic(err)
+	}
+	if price.Y.Min > ymin || price.Y.Max < ymax {
+		t.Errorf("nice Y range [%v, %v] does not contain [%v, %v]", price.Y.Min, price.Y.Max, ymin, ymax)
+	}
+	var majors []float64
+	for _, tick := range price.Y.Tick.Marker.Ticks(price.Y.Min, price.Y.Max) {
+		if !tick.IsMinor() {
+			majors = append(majors, tick.Value)
+		}
+	}
+	if len(majors) < 2 || majors[0] != price.Y.Min || majors[len(majors)-1] != price.Y.Max {
+		t.Errorf("nice Y range [%v, %v] does not end at major ticks %v", price.Y.Min, price.Y.Max, majors)
+	}
+
+	// only the X axes of the column
+	pymin, pymax := price.Y.Min, price.Y.Max
+	if err := UniteRanges(Column(plots, 0), nil, UniteOptions{X: true}); err != nil {
+		panic(err)
+	}
+	if volume.X.Min != testTOHLCVs[0].T || volume.X.Max != testTOHLCVs[len(testTOHLCVs)-1].T {
+		t.Errorf("unexpected X range of the volume plot [%v, %v]", volume.X.Min, volume.X.Max)
+	}
+	if price.X.Min != volume.X.Min || price.X.Max != volume.X.Max {
+		t.Errorf("X ranges differ: [%v, %v] != [%v, %v]", price.X.Min, price.X.Max, volume.X.Min, volume.X.Max)
+	}
+	if price.Y.Min != pymin || price.Y.Max != pymax {
+		t.Error("Y range changed although only X axes were united")
+	}
+}
+
+var (
+	_ WindowDataRanger = (*custplotter.Candlesticks)(nil)
+	_ WindowDataRanger = (*custplotter.OHLCBars)(nil)
+	_ WindowDataRanger = (*custplotter.VBars)(nil)
+	_ WindowDataRanger = (*custplotter.ZigZag)(nil)
+	_ WindowDataRanger = (*custplotter.TradeMarkers)(nil)
+	_ WindowDataRanger = (*custplotter.FibRetracement)(nil)
+)
+
+func TestUniteRangesWindowDataRanger(t *testing.T) {
+	testTOHLCVs := internal.CreateTOHLCVTestData()
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	zigzag, err := custplotter.NewZigZag([]custplotter.SwingPoint{
+		{T: testTOHLCVs[0].T, Price: 90},
+		{T: testTOHLCVs[5].T, Price: 110},
+		{T: testTOHLCVs[10].T, Price: 100},
+		{T: testTOHLCVs[15].T, Price: 105},
+	})
+	if err != nil {
+		panic(err)
+	}
+	p.Add(zigzag)
+
+	if err := UniteRanges([]*plot.Plot{p}, [][]plot.Plotter{{zigzag}}, UniteOptions{
+		Y: true, Window: true, XMin: testTOHLCVs[8].T, XMax: testTOHLCVs[19].T,
+	}); err != nil {
+		panic(err)
+	}
+	if p.Y.Min != 100 || p.Y.Max != 105 {
+		t.Errorf("unexpected Y range [%v, %v], want [100, 105]", p.Y.Min, p.Y.Max)
+	}
+
+	if err := UniteRanges([]*plot.Plot{p, p}, [][]plot.Plotter{{zigzag}}, UniteOptions{Y: true}); err == nil {
+		t.Error("expected error for too few plotter lists")
+	}
+}
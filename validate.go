@@ -0,0 +1,158 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// validWeight returns whether w is a positive finite weight.
+func validWeight(w float64) bool {
+	return w > 0 && !math.IsInf(w, 1)
+}
+
+// validateSizes returns an error if one of the sizes of the rows or
+// columns, named by kind, is invalid.
+func validateSizes(kind string, sizes []Size) error {
+	if len(sizes) == 0 {
+		return fmt.Errorf("plotext: table has no %ss", kind)
+	}
+	for i, s := range sizes {
+		switch {
+		case s.Auto:
+		case s.Abs < 0 || math.IsNaN(float64(s.Abs)) || math.IsInf(float64(s.Abs), 0):
+			return fmt.Errorf("plotext: absolute size %v of %s %d is not a non-negative length", s.Abs, kind, i)
+		case s.Abs > 0:
+		case !validWeight(s.Rel):
+			return fmt.Errorf("plotext: relative size %v of %s %d is not positive", s.Rel, kind, i)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the table is invalid, i.e. if it has no
+// rows or columns, if a relative size is not positive, if an absolute
+// size or a padding is negative. RowSizes and ColSizes are validated if
+// set, RowHeights and ColWidths otherwise.
+func (tab Table) Validate() error {
+	if err := validateSizes("row", tab.rowSizes()); err != nil {
+		return err
+	}
+	if err := validateSizes("column", tab.colSizes()); err != nil {
+		return err
+	}
+
+	pads := []struct {
+		name string
+		pad  vg.Length
+	}{
+		{"PadTop", tab.PadTop}, {"PadBottom", tab.PadBottom},
+		{"PadRight", tab.PadRight}, {"PadLeft", tab.PadLeft},
+		{"PadX", tab.PadX}, {"PadY", tab.PadY},
+	}
+	for _, p := range pads {
+		if p.pad < 0 || math.IsNaN(float64(p.pad)) {
+			return fmt.Errorf("plotext: %s (%v) is negative", p.name, p.pad)
+		}
+	}
+	return nil
+}
+
+// validateCanvas returns an error if the paddings
+// of the table do not fit into c.
+func (tab Table) validateCanvas(c draw.Canvas) error {
+	rows, cols := len(tab.rowSizes()), len(tab.colSizes())
+	if w := tab.PadLeft + tab.PadRight + vg.Length(cols-1)*tab.PadX; w >= c.Max.X-c.Min.X {
+		return fmt.Errorf("plotext: paddings (%v) do not fit into the width of the canvas (%v)", w, c.Max.X-c.Min.X)
+	}
+	if h := tab.PadTop + tab.PadBottom + vg.Length(rows-1)*tab.PadY; h >= c.Max.Y-c.Min.Y {
+		return fmt.Errorf("plotext: paddings (%v) do not fit into the height of the canvas (%v)", h, c.Max.Y-c.Min.Y)
+	}
+	return nil
+}
+
+// TryAt is like At, but it validates the table and
+// returns an error if x or y is out of range.
+func (tab Table) TryAt(c draw.Canvas, x, y int) (draw.Canvas, error) {
+	if tab.RowSizes != nil || tab.ColSizes != nil {
+		return draw.Canvas{}, fmt.Errorf("plotext: At does not support RowSizes and ColSizes")
+	}
+	if err := tab.Validate(); err != nil {
+		return draw.Canvas{}, err
+	}
+	if x < 0 || x >= len(tab.ColWidths) {
+		return draw.Canvas{}, fmt.Errorf("plotext: column %d out of range [0, %d)", x, len(tab.ColWidths))
+	}
+	if y < 0 || y >= len(tab.RowHeights) {
+		return draw.Canvas{}, fmt.Errorf("plotext: row %d out of range [0, %d)", y, len(tab.RowHeights))
+	}
+	return tab.At(c, x, y), nil
+}
+
+// TryAlign is like Align, but it validates the table and
+// the dimensions of plots up front and returns an error
+// instead of panicking.
+func (tab Table) TryAlign(plots [][]*plot.Plot, dc draw.Canvas) ([][]draw.Canvas, error) {
+	if err := tab.Validate(); err != nil {
+		return nil, err
+	}
+	rows, cols := len(tab.rowSizes()), len(tab.colSizes())
+	if len(plots) != rows {
+		return nil, fmt.Errorf("plotext: plots have %d rows, table has %d rows", len(plots), rows)
+	}
+	for j, row := range plots {
+		if len(row) != cols {
+			return nil, fmt.Errorf("plotext: row %d of plots has %d columns, table has %d columns", j, len(row), cols)
+		}
+	}
+	if err := tab.validateCanvas(dc); err != nil {
+		return nil, err
+	}
+	return tab.Align(plots, dc), nil
+}
+
+// validateCells returns an error if the table or one of the cells
+// is invalid. Nested tables are validated recursively.
+func (tab Table) validateCells(cells []Cell) error {
+	if err := tab.Validate(); err != nil {
+		return err
+	}
+
+	rows, cols := len(tab.rowSizes()), len(tab.colSizes())
+	for k, cell := range cells {
+		if cell.RowSpan < 0 || cell.ColSpan < 0 {
+			return fmt.Errorf("plotext: cell %d has negative span", k)
+		}
+		rowSpan, colSpan := cell.spans()
+		if cell.Row < 0 || cell.Row+rowSpan > rows || cell.Col < 0 || cell.Col+colSpan > cols {
+			return fmt.Errorf("plotext: cell %d at row %d, column %d spanning %d rows and %d columns is outside of the table (%d rows, %d columns)",
+				k, cell.Row, cell.Col, rowSpan, colSpan, rows, cols)
+		}
+		if cell.Plot == nil && cell.Nested != nil {
+			if err := cell.Nested.validateCells(cell.Nested.Cells); err != nil {
+				return fmt.Errorf("%v (in the nested table of cell %d)", err, k)
+			}
+		}
+	}
+	return nil
+}
+
+// TryAlignCells is like AlignCells, but it validates the table and the
+// cells, including nested tables, up front and returns an error instead
+// of panicking.
+func (tab Table) TryAlignCells(cells []Cell, dc draw.Canvas) ([]draw.Canvas, error) {
+	if err := tab.validateCells(cells); err != nil {
+		return nil, err
+	}
+	if err := tab.validateCanvas(dc); err != nil {
+		return nil, err
+	}
+	return tab.AlignCells(cells, dc), nil
+}
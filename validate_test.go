@@ -0,0 +1,111 @@
+// Copyright ©2018 Peter Paolucci. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotext
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		table Table
+		valid bool
+	}{
+		{"valid", Table{RowHeights: []float64{2, 1}, ColWidths: []float64{1}}, true},
+		{"no rows", Table{ColWidths: []float64{1}}, false},
+		{"no columns", Table{RowHeights: []float64{1}}, false},
+		{"zero height", Table{RowHeights: []float64{1, 0}, ColWidths: []float64{1}}, false},
+		{"negative width", Table{RowHeights: []float64{1}, ColWidths: []float64{-1}}, false},
+		{"NaN width", Table{RowHeights: []float64{1}, ColWidths: []float64{math.NaN()}}, false},
+		{"negative padding", Table{RowHeights: []float64{1}, ColWidths: []float64{1}, PadX: -1}, false},
+		{"valid sizes", Table{RowSizes: []Size{Auto(), Abs(vg.Inch), Rel(1)}, ColSizes: []Size{Rel(1)}}, true},
+		{"negative absolute size", Table{RowSizes: []Size{Abs(-1)}, ColSizes: []Size{Rel(1)}}, false},
+		{"zero relative size", Table{RowSizes: []Size{{}}, ColSizes: []Size{Rel(1)}}, false},
+	}
+
+	for _, test := range tests {
+		err := test.table.Validate()
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestTryAlign(t *testing.T) {
+	table := Table{
+		RowHeights: []float64{2, 1},
+		ColWidths:  []float64{1},
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+
+	img := vgimg.New(vg.Points(100), vg.Points(100))
+	dc := draw.New(img)
+
+	if _, err := table.TryAlign([][]*plot.Plot{{p}}, dc); err == nil {
+		t.Error("expected error for missing row")
+	}
+	if _, err := table.TryAlign([][]*plot.Plot{{p}, {p, p}}, dc); err == nil {
+		t.Error("expected error for extra column")
+	}
+	if _, err := table.TryAlign([][]*plot.Plot{{p}, {nil}}, dc); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := table.TryAt(dc, 1, 0); err == nil {
+		t.Error("expected error for column out of range")
+	}
+	if _, err := table.TryAt(dc, 0, 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	nested := &NestedTable{
+		Table: table,
+		Cells: []Cell{{Row: 2, Col: 0, Plot: p}},
+	}
+	if _, err := table.TryAlignCells([]Cell{{Row: 0, Col: 0, Nested: nested}}, dc); err == nil {
+		t.Error("expected error for cell out of range in nested table")
+	}
+	if _, err := table.TryAlignCells([]Cell{{Row: 0, Col: 0, RowSpan: 3, Plot: p}}, dc); err == nil {
+		t.Error("expected error for cell spanning beyond the table")
+	}
+
+	padded := table
+	padded.PadY = 200
+	if _, err := padded.TryAlign([][]*plot.Plot{{p}, {p}}, dc); err == nil {
+		t.Error("expected error for paddings not fitting into the canvas")
+	}
+}
+
+func TestAtSizesPanics(t *testing.T) {
+	table := Table{RowSizes: []Size{Rel(1)}, ColSizes: []Size{Rel(1)}}
+	dc := draw.New(vgimg.New(vg.Points(100), vg.Points(100)))
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected panic for a table with sizes only")
+		}
+		err, ok := r.(error)
+		if !ok || !strings.Contains(err.Error(), "RowSizes") {
+			t.Errorf("unexpected panic %v", r)
+		}
+	}()
+	table.AtSpan(dc, 0, 0, 1, 1)
+}